@@ -11,6 +11,7 @@ import (
 
 // -- boolSlice Value
 type boolSliceValue struct {
+	sliceLimits
 	value   *[]bool
 	changed bool
 }
@@ -87,6 +88,14 @@ func (s *boolSliceValue) toString(val bool) string {
 }
 
 func (s *boolSliceValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *boolSliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *boolSliceValue) appendValue(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
 		return err
@@ -95,7 +104,7 @@ func (s *boolSliceValue) Append(val string) error {
 	return nil
 }
 
-func (s *boolSliceValue) Replace(val []string) error {
+func (s *boolSliceValue) replaceValues(val []string) error {
 	out := make([]bool, len(val))
 	for i, d := range val {
 		var err error
@@ -116,6 +125,11 @@ func (s *boolSliceValue) GetSlice() []string {
 	return out
 }
 
+func (s *boolSliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetBoolSlice returns the []bool value of a flag with the given name.
 func (f *FlagSet) GetBoolSlice(name string) ([]bool, error) {
 	val, err := f.getFlagType(name, "boolSlice")
@@ -0,0 +1,144 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"os"
+	"strings"
+)
+
+// ColorMode defines whether usage output is styled with ANSI escape sequences.
+type ColorMode int
+
+const (
+	// ColorAuto styles usage output if Output() is a terminal and the NO_COLOR
+	// environment variable is not set (or empty).
+	ColorAuto ColorMode = iota
+	// ColorAlways always styles usage output.
+	ColorAlways
+	// ColorNever never styles usage output.
+	ColorNever
+)
+
+// ANSI escape sequences used by StyledFlagUsageFormatter.
+const (
+	StyleReset     = "\x1b[0m"
+	StyleBold      = "\x1b[1m"
+	StyleDim       = "\x1b[2m"
+	StyleUnderline = "\x1b[4m"
+	StyleYellow    = "\x1b[33m"
+)
+
+// GroupHeaderFormatter is an optional interface for a FlagUsageFormatter to
+// format the title of a flag group.
+type GroupHeaderFormatter interface {
+	GroupHeader(title string) string
+}
+
+// StyledFlagUsageFormatter decorates the output of another FlagUsageFormatter
+// with ANSI escape sequences: bold flag names, dim default values, colored
+// deprecation notes and bold, underlined group headers.
+type StyledFlagUsageFormatter struct {
+	// Base is the formatter whose output is styled. DefaultFlagUsageFormatter
+	// is used if it is nil.
+	Base FlagUsageFormatter
+}
+
+var _ FlagUsageFormatter = (*StyledFlagUsageFormatter)(nil)
+var _ GroupHeaderFormatter = (*StyledFlagUsageFormatter)(nil)
+var _ ExperimentalFormatter = (*StyledFlagUsageFormatter)(nil)
+var _ ConstraintFormatter = (*StyledFlagUsageFormatter)(nil)
+
+func (s StyledFlagUsageFormatter) base() FlagUsageFormatter {
+	if s.Base == nil {
+		return DefaultFlagUsageFormatter{}
+	}
+	return s.Base
+}
+
+func (s StyledFlagUsageFormatter) Name(flag *Flag) string {
+	return styleText(s.base().Name(flag), StyleBold)
+}
+
+func (s StyledFlagUsageFormatter) Usage(flag *Flag, usage string) string {
+	return s.base().Usage(flag, usage)
+}
+
+func (s StyledFlagUsageFormatter) UsageVarName(flag *Flag, varname string) string {
+	return s.base().UsageVarName(flag, varname)
+}
+
+func (s StyledFlagUsageFormatter) DefaultValue(flag *Flag) string {
+	return styleText(s.base().DefaultValue(flag), StyleDim)
+}
+
+func (s StyledFlagUsageFormatter) NoOptDefValue(flag *Flag) string {
+	return s.base().NoOptDefValue(flag)
+}
+
+func (s StyledFlagUsageFormatter) Deprecated(flag *Flag) string {
+	return styleText(s.base().Deprecated(flag), StyleYellow)
+}
+
+func (s StyledFlagUsageFormatter) Constraint(flag *Flag) string {
+	return styleText(constraintNote(s.base(), flag), StyleDim)
+}
+
+func (s StyledFlagUsageFormatter) Experimental(flag *Flag) string {
+	return styleText(experimentalNote(s.base(), flag), StyleYellow)
+}
+
+func (s StyledFlagUsageFormatter) GroupHeader(title string) string {
+	if h, ok := s.base().(GroupHeaderFormatter); ok {
+		title = h.GroupHeader(title)
+	}
+	return styleText(title, StyleBold+StyleUnderline)
+}
+
+// styleText wraps s in the given style, leaving leading and trailing
+// whitespace unstyled.
+func styleText(s, style string) string {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return s
+	}
+	start := strings.Index(s, trimmed)
+	end := start + len(trimmed)
+	return s[:start] + style + trimmed + StyleReset + s[end:]
+}
+
+// ansiLen returns the length of the ANSI escape sequence at the start of s,
+// or 0 if s does not start with one.
+func ansiLen(s string) int {
+	if len(s) < 2 || s[0] != '\x1b' {
+		return 0
+	}
+	if s[1] != '[' {
+		// two character escape sequence
+		return 2
+	}
+	// control sequence, terminated by a byte in the range 0x40-0x7E
+	for i := 2; i < len(s); i++ {
+		if s[i] >= 0x40 && s[i] <= 0x7E {
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// ColorEnabled reports whether the usage output of the FlagSet is styled.
+// See ColorMode.
+func (f *FlagSet) ColorEnabled() bool {
+	switch f.Color {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return isTerminal(f.Output())
+}
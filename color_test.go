@@ -0,0 +1,95 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var ansiRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func TestColorEnabled(t *testing.T) {
+	oldNoColor, hadNoColor := os.LookupEnv("NO_COLOR")
+	defer func() {
+		if hadNoColor {
+			os.Setenv("NO_COLOR", oldNoColor)
+		} else {
+			os.Unsetenv("NO_COLOR")
+		}
+	}()
+	os.Unsetenv("NO_COLOR")
+
+	var buf bytes.Buffer
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetOutput(&buf)
+
+	if fs.ColorEnabled() {
+		t.Error("expected no color when the output is not a terminal")
+	}
+
+	fs.Color = ColorAlways
+	if !fs.ColorEnabled() {
+		t.Error("expected color with ColorAlways")
+	}
+
+	fs.Color = ColorNever
+	if fs.ColorEnabled() {
+		t.Error("expected no color with ColorNever")
+	}
+
+	os.Setenv("NO_COLOR", "1")
+	fs.Color = ColorAuto
+	fs.SetOutput(os.Stdout)
+	if fs.ColorEnabled() {
+		t.Error("expected no color when NO_COLOR is set")
+	}
+}
+
+func TestStyledUsage(t *testing.T) {
+	var buf bytes.Buffer
+	plain := setUpZFlagSet2(&buf)
+	plain.String("old", "x", "old", OptDeprecated("use new"))
+	plain.Lookup("old").Hidden = false
+	want := plain.FlagUsagesWrapped(80)
+
+	styled := setUpZFlagSet2(&buf)
+	styled.String("old", "x", "old", OptDeprecated("use new"))
+	styled.Lookup("old").Hidden = false
+	styled.Color = ColorAlways
+	got := styled.FlagUsagesWrapped(80)
+
+	if !strings.Contains(got, StyleBold+"-s, --long-name"+StyleReset) {
+		t.Errorf("expected bold flag names; got %q", got)
+	}
+	if !strings.Contains(got, StyleDim+`(default "test")`+StyleReset) {
+		t.Errorf("expected dim default values; got %q", got)
+	}
+	if !strings.Contains(got, StyleYellow+"(DEPRECATED: use new)"+StyleReset) {
+		t.Errorf("expected colored deprecation notes; got %q", got)
+	}
+	if stripped := ansiRegexp.ReplaceAllString(got, ""); stripped != want {
+		t.Errorf("expected the same layout as without color\nexpected:\n%s\ngot:\n%s", want, stripped)
+	}
+}
+
+func TestStyledGroupHeader(t *testing.T) {
+	got := StyledFlagUsageFormatter{}.GroupHeader("Advanced")
+	if want := StyleBold + StyleUnderline + "Advanced:" + StyleReset; got != want {
+		t.Errorf("expected %q; got %q", want, got)
+	}
+}
+
+func TestDisplayWidthIgnoresEscapes(t *testing.T) {
+	s := StyleBold + "abc" + StyleReset + " " + StyleDim + "日本" + StyleReset
+	if got := displayWidth(s); got != 8 {
+		t.Errorf("expected width 8; got %d", got)
+	}
+	if got := displayIndex(s, 3); s[:got] != StyleBold+"abc"+StyleReset {
+		t.Errorf("unexpected prefix %q", s[:got])
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 // -- complex128Slice Value
 type complex128SliceValue struct {
+	sliceLimits
 	value   *[]complex128
 	changed bool
 }
@@ -70,6 +71,14 @@ func (s *complex128SliceValue) toString(val complex128) string {
 }
 
 func (s *complex128SliceValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *complex128SliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *complex128SliceValue) appendValue(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
 		return err
@@ -78,7 +87,7 @@ func (s *complex128SliceValue) Append(val string) error {
 	return nil
 }
 
-func (s *complex128SliceValue) Replace(val []string) error {
+func (s *complex128SliceValue) replaceValues(val []string) error {
 	out := make([]complex128, len(val))
 	for i, d := range val {
 		var err error
@@ -99,6 +108,11 @@ func (s *complex128SliceValue) GetSlice() []string {
 	return out
 }
 
+func (s *complex128SliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetComplex128Slice return the []complex128 value of a flag with the given name
 func (f *FlagSet) GetComplex128Slice(name string) ([]complex128, error) {
 	val, err := f.getFlagType(name, "complex128Slice")
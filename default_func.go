@@ -0,0 +1,79 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultFunc computes the default value of a flag, see OptDefaultFunc.
+type DefaultFunc func(fs *FlagSet) (string, error)
+
+// ApplyDefaultFuncs sets the values computed by the DefaultFunc of flags that
+// were not set or implied, see OptDefaultFunc. The flags a DefaultFunc depends on are
+// computed first. The flags keep SourceDefault and are not marked as Changed.
+// It is called by Parse after the implied values were applied.
+func (f *FlagSet) ApplyDefaultFuncs() error {
+	const (
+		pending = iota
+		evaluating
+		evaluated
+	)
+	state := make(map[*Flag]int)
+	var path []string
+
+	var evaluate func(flag *Flag) error
+	evaluate = func(flag *Flag) error {
+		switch state[flag] {
+		case evaluating:
+			return fmt.Errorf("default value dependency cycle: --%s -> --%s", strings.Join(path, " -> --"), flag.Name)
+		case evaluated:
+			return nil
+		}
+		state[flag] = evaluating
+		path = append(path, flag.Name)
+
+		for _, name := range flag.DefaultDeps {
+			dep := f.Lookup(name)
+			if dep == nil {
+				return fmt.Errorf("default value of flag --%s depends on unknown flag --%s", flag.Name, name)
+			}
+			if err := evaluate(dep); err != nil {
+				return err
+			}
+		}
+
+		if flag.DefaultFunc != nil && !flag.isSet() {
+			value, err := flag.DefaultFunc(f)
+			if err != nil {
+				return fmt.Errorf("default value of flag --%s: %v", flag.Name, err)
+			}
+			if err = flag.setValidated(func() error { return flag.Value.Set(value) }); err != nil {
+				return fmt.Errorf("invalid default value %q for flag --%s: %v", value, flag.Name, err)
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[flag] = evaluated
+		return nil
+	}
+
+	var err error
+	f.VisitAll(func(flag *Flag) {
+		if err == nil && flag.DefaultFunc != nil {
+			err = evaluate(flag)
+		}
+	})
+	return err
+}
+
+// applyDefaultFuncs is ApplyDefaultFuncs for parsing, presenting errors as
+// configured.
+func (f *FlagSet) applyDefaultFuncs() error {
+	if err := f.ApplyDefaultFuncs(); err != nil {
+		return f.fail(&ParseError{Category: ErrorOther, Err: err})
+	}
+	return nil
+}
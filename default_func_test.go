@@ -0,0 +1,103 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setUpDefaultFuncFlagSet(calls *int) *FlagSet {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.String("index-dir", "", "index directory",
+		OptDefaultFunc(func(fs *FlagSet) (string, error) {
+			*calls++
+			return filepath.Join(fs.MustGetString("cache-dir"), "index"), nil
+		}, "cache-dir"),
+		OptDefValue("<cache-dir>/index"),
+	)
+	fs.String("cache-dir", "", "cache directory",
+		OptDefaultFunc(func(fs *FlagSet) (string, error) {
+			*calls++
+			return filepath.Join(fs.MustGetString("data-dir"), "cache"), nil
+		}, "data-dir"),
+		OptDefValue("<data-dir>/cache"),
+	)
+	fs.String("data-dir", "/var/lib/app", "data directory")
+	fs.SetErrorPresentation(ErrorSilent)
+	return fs
+}
+
+func TestDefaultFunc(t *testing.T) {
+	var calls int
+	fs := setUpDefaultFuncFlagSet(&calls)
+	if err := fs.Parse([]string{"--data-dir=/data"}); err != nil {
+		t.Fatal(err)
+	}
+	if v := fs.MustGetString("cache-dir"); v != filepath.Join("/data", "cache") {
+		t.Errorf("unexpected cache dir %q", v)
+	}
+	if v := fs.MustGetString("index-dir"); v != filepath.Join("/data", "cache", "index") {
+		t.Errorf("expected the dependency to be computed first; got %q", v)
+	}
+	if calls != 2 {
+		t.Errorf("expected each func to be called once; got %d calls", calls)
+	}
+	if flag := fs.Lookup("cache-dir"); flag.Changed || flag.Source != SourceDefault {
+		t.Error("expected computed defaults to not change the flag")
+	}
+
+	calls = 0
+	fs = setUpDefaultFuncFlagSet(&calls)
+	if err := fs.Parse([]string{"--cache-dir=/tmp/cache"}); err != nil {
+		t.Fatal(err)
+	}
+	if v := fs.MustGetString("cache-dir"); v != "/tmp/cache" {
+		t.Errorf("expected the explicit value to win; got %q", v)
+	}
+	if v := fs.MustGetString("index-dir"); v != filepath.Join("/tmp/cache", "index") {
+		t.Errorf("unexpected index dir %q", v)
+	}
+	if calls != 1 {
+		t.Errorf("expected only the unset flag to be computed; got %d calls", calls)
+	}
+}
+
+func TestDefaultFuncUsage(t *testing.T) {
+	var calls int
+	fs := setUpDefaultFuncFlagSet(&calls)
+	usage := fs.FlagUsages()
+	if !strings.Contains(usage, `cache directory (default "<data-dir>/cache")`) {
+		t.Errorf("expected the placeholder in the usage; got:\n%s", usage)
+	}
+	if calls != 0 {
+		t.Errorf("expected no calls for usage; got %d", calls)
+	}
+}
+
+func TestDefaultFuncErrors(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.String("a", "", "a", OptDefaultFunc(func(fs *FlagSet) (string, error) { return "", nil }, "b"))
+	fs.String("b", "", "b", OptDefaultFunc(func(fs *FlagSet) (string, error) { return "", nil }, "a"))
+	fs.SetErrorPresentation(ErrorSilent)
+	if err := fs.Parse(nil); err == nil || err.Error() != "default value dependency cycle: --a -> --b -> --a" {
+		t.Errorf("expected a cycle error; got %v", err)
+	}
+
+	fs = NewFlagSet("test", ContinueOnError)
+	fs.Int("n", 0, "n", OptDefaultFunc(func(fs *FlagSet) (string, error) { return "", errors.New("lookup failed") }))
+	fs.SetErrorPresentation(ErrorSilent)
+	if err := fs.Parse(nil); err == nil || err.Error() != "default value of flag --n: lookup failed" {
+		t.Errorf("expected the func error; got %v", err)
+	}
+
+	fs = NewFlagSet("test", ContinueOnError)
+	fs.Int("n", 0, "n", OptDefaultFunc(func(fs *FlagSet) (string, error) { return "many", nil }))
+	fs.SetErrorPresentation(ErrorSilent)
+	if err := fs.Parse(nil); err == nil || !strings.HasPrefix(err.Error(), `invalid default value "many" for flag --n`) {
+		t.Errorf("expected an invalid value error; got %v", err)
+	}
+}
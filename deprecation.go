@@ -0,0 +1,173 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValueTransformFunc transforms the value of a flag, see OptReplacedBy.
+type ValueTransformFunc func(value string) (string, error)
+
+// IsDeprecated reports whether the flag is deprecated, either with a message
+// or with one of the deprecation lifecycle options.
+func (f *Flag) IsDeprecated() bool {
+	return f.Deprecated != "" || f.DeprecatedSince != "" || f.RemovedIn != "" || f.ReplacedBy != ""
+}
+
+// DeprecationNote returns the deprecation note of the flag, e.g.
+// "since v2.3, will be removed in v3.0, use --new-flag instead". Without
+// lifecycle options it is the Deprecated message.
+func (f *Flag) DeprecationNote() string {
+	var parts []string
+	if f.DeprecatedSince != "" {
+		parts = append(parts, "since "+f.DeprecatedSince)
+	}
+	if f.RemovedIn != "" {
+		parts = append(parts, "will be removed in "+f.RemovedIn)
+	}
+	if f.Deprecated != "" {
+		parts = append(parts, f.Deprecated)
+	} else if f.ReplacedBy != "" {
+		parts = append(parts, "use --"+f.ReplacedBy+" instead")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// errFlagRemoved is returned when a flag is used after the version it was
+// removed in, see FlagSet.StrictDeprecation.
+type errFlagRemoved struct {
+	flag *Flag
+}
+
+func (e errFlagRemoved) Error() string {
+	msg := fmt.Sprintf("flag --%s was removed in %s", e.flag.Name, e.flag.RemovedIn)
+	if e.flag.Deprecated != "" {
+		return msg + ", " + e.flag.Deprecated
+	} else if e.flag.ReplacedBy != "" {
+		return msg + ", use --" + e.flag.ReplacedBy + " instead"
+	}
+	return msg
+}
+
+// appVersion returns the version deprecations are checked against.
+func (f *FlagSet) appVersion() string {
+	if f.AppVersion != "" {
+		return f.AppVersion
+	}
+	if f.version != nil {
+		return f.version.Version
+	}
+	return ""
+}
+
+// checkRemoved fails in strict mode once the deprecated flag was removed. It
+// is called before the value of the flag is set.
+func (f *FlagSet) checkRemoved(flag *Flag) error {
+	if f.StrictDeprecation && flag.RemovedIn != "" {
+		if version := f.appVersion(); version != "" && compareVersions(version, flag.RemovedIn) >= 0 {
+			return errFlagRemoved{flag: flag}
+		}
+	}
+	return nil
+}
+
+// warnDeprecated emits a WarningDeprecatedFlag warning for the use of a
+// deprecated flag. It is called before the value of the flag is set, so a
+// warning turned into an error leaves the flag unset.
+func (f *FlagSet) warnDeprecated(flag *Flag) error {
+	return f.warn(&Warning{
+		Kind:    WarningDeprecatedFlag,
+		Flag:    flag,
+		Message: fmt.Sprintf("Flag --%s has been deprecated, %s", flag.Name, flag.DeprecationNote()),
+	})
+}
+
+// forwardDeprecated forwards the value set on a deprecated flag to its
+// replacement, unless the replacement was set itself.
+func (f *FlagSet) forwardDeprecated(flag *Flag, value string, source ValueSource) error {
+	replacement := f.Lookup(flag.ReplacedBy)
+	if replacement == nil || (replacement.Changed && !f.forwarded[replacement] && replacement.Source.priority() >= source.priority()) {
+		return nil
+	}
+
+	if flag.ReplaceValue != nil {
+		replaced, err := flag.ReplaceValue(value)
+		if err != nil {
+			return fmt.Errorf("invalid argument %q for %q flag: %v", value, "--"+flag.Name, err)
+		}
+		value = replaced
+	}
+	if err := f.set(replacement, value, source); err != nil {
+		return err
+	}
+	if f.forwarded == nil {
+		f.forwarded = make(map[*Flag]bool)
+	}
+	f.forwarded[replacement] = true
+	return nil
+}
+
+// compareVersions compares two versions such as "v1.2.3", returning -1, 0 or
+// +1. Numeric components are compared as numbers, a version with a
+// pre-release suffix such as "-rc1" is lower than the same version without.
+func compareVersions(a, b string) int {
+	a, aPre := splitPrerelease(strings.TrimPrefix(a, "v"))
+	b, bPre := splitPrerelease(strings.TrimPrefix(b, "v"))
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		x, y := "0", "0"
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+		if c := compareVersionPart(x, y); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+	return compareVersionPart(aPre, bPre)
+}
+
+func splitPrerelease(v string) (string, string) {
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		if v[i] == '+' {
+			return v[:i], ""
+		}
+		pre := v[i+1:]
+		if j := strings.IndexByte(pre, '+'); j >= 0 {
+			pre = pre[:j]
+		}
+		return v[:i], pre
+	}
+	return v, ""
+}
+
+func compareVersionPart(x, y string) int {
+	xn, xerr := strconv.Atoi(x)
+	yn, yerr := strconv.Atoi(y)
+	if xerr == nil && yerr == nil {
+		switch {
+		case xn < yn:
+			return -1
+		case xn > yn:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(x, y)
+}
@@ -0,0 +1,175 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func newLifecycleFlagSet() (*FlagSet, *bytes.Buffer) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.Duration("timeout", 0, "timeout")
+	fs.Int("timeout-ms", 1000, "timeout in milliseconds",
+		OptDeprecatedSince("v2.3"),
+		OptRemovedIn("v3.0"),
+		OptReplacedBy("timeout", func(value string) (string, error) {
+			ms, err := strconv.Atoi(value)
+			if err != nil {
+				return "", err
+			}
+			return strconv.Itoa(ms) + "ms", nil
+		}),
+	)
+	fs.SetErrorPresentation(ErrorShowHint)
+	buf := new(bytes.Buffer)
+	fs.SetOutput(buf)
+	return fs, buf
+}
+
+func TestDeprecationNote(t *testing.T) {
+	tests := []struct {
+		flag     Flag
+		expected string
+	}{
+		{Flag{Deprecated: "use --new"}, "use --new"},
+		{Flag{DeprecatedSince: "v2.3"}, "since v2.3"},
+		{Flag{DeprecatedSince: "v2.3", RemovedIn: "v3.0", ReplacedBy: "new"}, "since v2.3, will be removed in v3.0, use --new instead"},
+		{Flag{RemovedIn: "v3.0", ReplacedBy: "new", Deprecated: "use --new with a duration"}, "will be removed in v3.0, use --new with a duration"},
+	}
+	for _, test := range tests {
+		if !test.flag.IsDeprecated() {
+			t.Errorf("expected %+v to be deprecated", test.flag)
+		}
+		if note := test.flag.DeprecationNote(); note != test.expected {
+			t.Errorf("expected %q; got %q", test.expected, note)
+		}
+	}
+	if (&Flag{}).IsDeprecated() {
+		t.Error("expected a flag without deprecation to not be deprecated")
+	}
+}
+
+func TestDeprecationForwarding(t *testing.T) {
+	fs, buf := newLifecycleFlagSet()
+	if err := fs.Parse([]string{"--timeout-ms", "1500"}); err != nil {
+		t.Fatal(err)
+	}
+	if d := fs.MustGetDuration("timeout"); d.String() != "1.5s" {
+		t.Errorf("expected the value to be forwarded; got %v", d)
+	}
+	if !fs.Lookup("timeout").Changed {
+		t.Error("expected the replacement to be changed")
+	}
+	expected := "Flag --timeout-ms has been deprecated, since v2.3, will be removed in v3.0, use --timeout instead\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q; got %q", expected, buf.String())
+	}
+
+	fs, _ = newLifecycleFlagSet()
+	err := fs.Parse([]string{"--timeout-ms", "soon"})
+	if ErrorCategoryOf(err) != ErrorInvalidValue || !strings.HasPrefix(err.Error(), `invalid argument "soon" for "--timeout-ms" flag`) {
+		t.Errorf("expected an invalid value error; got %v", err)
+	}
+}
+
+func TestStrictDeprecation(t *testing.T) {
+	for _, version := range []string{"v2.9.1", "v3.0.0-rc1"} {
+		fs, _ := newLifecycleFlagSet()
+		fs.StrictDeprecation = true
+		fs.AppVersion = version
+		if err := fs.Parse([]string{"--timeout-ms", "1500"}); err != nil {
+			t.Errorf("%s: expected the deprecated flag to work; got %v", version, err)
+		}
+	}
+
+	fs, buf := newLifecycleFlagSet()
+	fs.StrictDeprecation = true
+	fs.SetVersion(VersionInfo{Version: "v3.0.0"})
+	err := fs.Parse([]string{"--timeout-ms", "1500"})
+	if ErrorCategoryOf(err) != ErrorRemovedFlag {
+		t.Fatalf("expected a removed flag error; got %v", err)
+	}
+	expected := "flag --timeout-ms was removed in v3.0, use --timeout instead\nRun 'test --help' for usage.\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q; got %q", expected, buf.String())
+	}
+	if fs.Lookup("timeout").Changed {
+		t.Error("expected the value not to be forwarded")
+	}
+	if flag := fs.Lookup("timeout-ms"); flag.Changed || flag.Value.String() != "1000" {
+		t.Errorf("expected the removed flag not to be set; got %s", flag.Value)
+	}
+}
+
+func TestDeprecationForwardingExplicitReplacement(t *testing.T) {
+	for _, args := range [][]string{
+		{"--timeout=2s", "--timeout-ms=1500"},
+		{"--timeout-ms=1500", "--timeout=2s"},
+	} {
+		fs, _ := newLifecycleFlagSet()
+		if err := fs.Parse(args); err != nil {
+			t.Fatal(err)
+		}
+		if d := fs.MustGetDuration("timeout"); d.String() != "2s" {
+			t.Errorf("%v: expected the explicit value to be kept; got %v", args, d)
+		}
+	}
+
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetOutput(new(bytes.Buffer))
+	hosts := fs.StringSlice("hosts", nil, "hosts")
+	fs.StringSlice("host", nil, "host", OptReplacedBy("hosts", nil))
+	if err := fs.Parse([]string{"--host=a", "--host=b"}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(*hosts, ",") != "a,b" {
+		t.Errorf("expected every value to be forwarded; got %v", *hosts)
+	}
+}
+
+func TestDeprecationUndefinedReplacement(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an undefined replacement")
+		}
+	}()
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.Int("timeout-ms", 0, "timeout in milliseconds", OptReplacedBy("timeout", nil))
+}
+
+func TestDeprecationUsage(t *testing.T) {
+	fs, _ := newLifecycleFlagSet()
+	expected := "      --timeout duration   timeout\n" +
+		"      --timeout-ms int     timeout in milliseconds (default 1000) (DEPRECATED: since v2.3, will be removed in v3.0, use --timeout instead)\n"
+	if usage := fs.FlagUsages(); usage != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, usage)
+	}
+	if synopsis := fs.Synopsis(); synopsis != "test [-h] [--timeout duration]" {
+		t.Errorf("expected the deprecated flag to be left out of the synopsis; got %q", synopsis)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"v1.2.3", "v1.2.3", 0},
+		{"1.2.3", "v1.2.3", 0},
+		{"v1.2", "v1.2.0", 0},
+		{"v1.10.0", "v1.9.0", 1},
+		{"v2.0", "v10.0", -1},
+		{"v3.0.0-rc1", "v3.0.0", -1},
+		{"v3.0.0-rc2", "v3.0.0-rc1", 1},
+		{"v3.0.0+build", "v3.0.0", 0},
+	}
+	for _, test := range tests {
+		if c := compareVersions(test.a, test.b); c != test.expected {
+			t.Errorf("compareVersions(%q, %q): expected %d; got %d", test.a, test.b, test.expected, c)
+		}
+	}
+}
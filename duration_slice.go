@@ -10,6 +10,7 @@ import (
 
 // -- durationSlice Value
 type durationSliceValue struct {
+	sliceLimits
 	value   *[]time.Duration
 	changed bool
 }
@@ -66,6 +67,14 @@ func (s *durationSliceValue) toString(val time.Duration) string {
 }
 
 func (s *durationSliceValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *durationSliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *durationSliceValue) appendValue(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
 		return err
@@ -74,7 +83,7 @@ func (s *durationSliceValue) Append(val string) error {
 	return nil
 }
 
-func (s *durationSliceValue) Replace(val []string) error {
+func (s *durationSliceValue) replaceValues(val []string) error {
 	out := make([]time.Duration, len(val))
 	for i, d := range val {
 		var err error
@@ -95,6 +104,11 @@ func (s *durationSliceValue) GetSlice() []string {
 	return out
 }
 
+func (s *durationSliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetDurationSlice returns the []time.Duration value of a flag with the given name
 func (f *FlagSet) GetDurationSlice(name string) ([]time.Duration, error) {
 	val, err := f.getFlagType(name, "durationSlice")
@@ -0,0 +1,328 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EnumChoicesAnnotation is the annotation listing the canonical choices of
+// enum flags, for completion.
+const EnumChoicesAnnotation = "zflag_enum_choices"
+
+// Choice is a value accepted by an enum flag, see EnumVar.
+type Choice struct {
+	// Value is the canonical value, returned by the getters.
+	Value string
+	// Aliases are accepted in place of Value.
+	Aliases []string
+	// Description is shown by --help=<flag>.
+	Description string
+}
+
+// Choices returns choices for the given values, without aliases or
+// descriptions.
+func Choices(values ...string) []Choice {
+	choices := make([]Choice, len(values))
+	for i, value := range values {
+		choices[i] = Choice{Value: value}
+	}
+	return choices
+}
+
+// enumChoices are the choices shared by the enum values.
+type enumChoices struct {
+	choices         []Choice
+	caseInsensitive bool
+}
+
+// enumValuer is implemented by the enum values.
+type enumValuer interface {
+	choiceSet() *enumChoices
+}
+
+func (e *enumChoices) equal(a, b string) bool {
+	if e.caseInsensitive {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// values returns the canonical values of the choices.
+func (e *enumChoices) values() []string {
+	values := make([]string, len(e.choices))
+	for i, choice := range e.choices {
+		values[i] = choice.Value
+	}
+	return values
+}
+
+// canonical returns the canonical value of the choice matching val.
+func (e *enumChoices) canonical(val string) (string, error) {
+	for _, choice := range e.choices {
+		if e.equal(val, choice.Value) {
+			return choice.Value, nil
+		}
+		for _, alias := range choice.Aliases {
+			if e.equal(val, alias) {
+				return choice.Value, nil
+			}
+		}
+	}
+
+	msg := fmt.Sprintf("%q is not one of %s", val, strings.Join(e.values(), "|"))
+	if suggestion := e.suggest(val); suggestion != "" {
+		msg += fmt.Sprintf(", did you mean %q?", suggestion)
+	}
+	return "", fmt.Errorf("%s", msg)
+}
+
+// suggest returns the canonical value of the choice closest to val, or "" if
+// no choice is close.
+func (e *enumChoices) suggest(val string) string {
+	if e.caseInsensitive {
+		val = strings.ToLower(val)
+	}
+
+	best, bestDistance := "", 0
+	for _, choice := range e.choices {
+		for _, candidate := range append([]string{choice.Value}, choice.Aliases...) {
+			if e.caseInsensitive {
+				candidate = strings.ToLower(candidate)
+			}
+			distance := levenshtein(val, candidate)
+			if distance > 2 || distance*2 > len(candidate) {
+				continue
+			}
+			if best == "" || distance < bestDistance {
+				best, bestDistance = choice.Value, distance
+			}
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	row := make([]int, len(t)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			cur := row[j]
+			row[j] = min3(row[j]+1, row[j-1]+1, prev+cost)
+			prev = cur
+		}
+	}
+	return row[len(t)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// Choices returns the choices of an enum flag, or nil for other flags.
+func (f *Flag) Choices() []Choice {
+	if e, ok := f.Value.(enumValuer); ok {
+		return e.choiceSet().choices
+	}
+	return nil
+}
+
+// usageType formats the choices for UnquoteUsage, e.g. "{json|yaml|text}".
+func (e *enumChoices) usageType() string {
+	return "{" + strings.Join(e.values(), "|") + "}"
+}
+
+// longUsage lists the choices with their aliases and descriptions, or is
+// empty if there are none.
+func (e *enumChoices) longUsage() string {
+	var lines []string
+	described := false
+	for _, choice := range e.choices {
+		line := choice.Value
+		if len(choice.Aliases) > 0 {
+			line += " (" + strings.Join(choice.Aliases, ", ") + ")"
+		}
+		if choice.Description != "" {
+			line += ": " + choice.Description
+		}
+		described = described || len(choice.Aliases) > 0 || choice.Description != ""
+		lines = append(lines, line)
+	}
+	if !described {
+		return ""
+	}
+	return strings.Join(lines, "\n")
+}
+
+// check returns an error if a choice or an alias matches another choice.
+func (e *enumChoices) check() error {
+	owners := make(map[string]int)
+	for i, choice := range e.choices {
+		for j, name := range append([]string{choice.Value}, choice.Aliases...) {
+			key := name
+			if e.caseInsensitive {
+				key = strings.ToLower(name)
+			}
+			owner, seen := owners[key]
+			switch {
+			case !seen:
+				owners[key] = i
+			case j == 0 && owner != i:
+				return fmt.Errorf("duplicate choice %q", name)
+			case owner != i:
+				return fmt.Errorf("alias %q of choice %q collides with choice %q", name, choice.Value, e.choices[owner].Value)
+			}
+		}
+	}
+	return nil
+}
+
+// enumDefaulter is implemented by the enum values to check their default
+// value once the options of the flag were applied.
+type enumDefaulter interface {
+	enumValuer
+	canonicalize() error
+}
+
+type optEnumDefaultImpl struct{}
+
+func (o optEnumDefaultImpl) apply(c *Flag) error {
+	e := c.Value.(enumDefaulter)
+	if err := e.choiceSet().check(); err != nil {
+		return fmt.Errorf("flag %q: %v", c.Name, err)
+	}
+	if err := e.canonicalize(); err != nil {
+		return fmt.Errorf("invalid default value for flag %q: %v", c.Name, err)
+	}
+	c.DefValue = c.Value.String()
+	return nil
+}
+
+// enumOpts returns the options every enum flag is defined with around opts,
+// checking the choices and the default value after opts such as
+// OptCaseInsensitive were applied.
+func enumOpts(e *enumChoices, opts []Opt) []Opt {
+	enumOpts := []Opt{OptAnnotation(EnumChoicesAnnotation, e.values())}
+	if longUsage := e.longUsage(); longUsage != "" {
+		enumOpts = append(enumOpts, OptLongUsage(longUsage))
+	}
+	enumOpts = append(enumOpts, opts...)
+	return append(enumOpts, optEnumDefaultImpl{})
+}
+
+// -- enum Value
+type enumValue struct {
+	enumChoices
+	value *string
+}
+
+func newEnumValue(val string, choices []Choice, p *string) *enumValue {
+	e := &enumValue{enumChoices: enumChoices{choices: choices}, value: p}
+	*p = val
+	return e
+}
+
+func (e *enumValue) choiceSet() *enumChoices {
+	return &e.enumChoices
+}
+
+// canonicalize replaces the current value with its canonical choice.
+func (e *enumValue) canonicalize() error {
+	if *e.value == "" {
+		return nil
+	}
+	v, err := e.canonical(*e.value)
+	if err != nil {
+		return err
+	}
+	*e.value = v
+	return nil
+}
+
+func (e *enumValue) Set(val string) error {
+	v, err := e.canonical(val)
+	if err != nil {
+		return err
+	}
+	*e.value = v
+	return nil
+}
+
+func (e *enumValue) Get() interface{} {
+	return *e.value
+}
+
+func (e *enumValue) Type() string {
+	return "enum"
+}
+
+func (e *enumValue) String() string { return *e.value }
+
+// GetEnum return the canonical choice of an enum flag with the given name
+func (f *FlagSet) GetEnum(name string) (string, error) {
+	val, err := f.getFlagType(name, "enum")
+	if err != nil {
+		return "", err
+	}
+	return val.(string), nil
+}
+
+// MustGetEnum is like GetEnum, but panics on error.
+func (f *FlagSet) MustGetEnum(name string) string {
+	val, err := f.GetEnum(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// EnumVar defines an enum flag with specified name, default value, choices,
+// and usage string. The argument p points to a string variable in which to
+// store the canonical value of the choice. Values that are not a choice or an
+// alias of one are rejected, see OptCaseInsensitive.
+func (f *FlagSet) EnumVar(p *string, name string, value string, choices []Choice, usage string, opts ...Opt) {
+	e := newEnumValue(value, choices, p)
+	f.Var(e, name, usage, enumOpts(&e.enumChoices, opts)...)
+}
+
+// EnumVar defines an enum flag with specified name, default value, choices,
+// and usage string. The argument p points to a string variable in which to
+// store the canonical value of the choice. Values that are not a choice or an
+// alias of one are rejected, see OptCaseInsensitive.
+func EnumVar(p *string, name string, value string, choices []Choice, usage string, opts ...Opt) {
+	CommandLine.EnumVar(p, name, value, choices, usage, opts...)
+}
+
+// Enum defines an enum flag with specified name, default value, choices, and
+// usage string. The return value is the address of a string variable that
+// stores the canonical value of the choice.
+func (f *FlagSet) Enum(name string, value string, choices []Choice, usage string, opts ...Opt) *string {
+	var p string
+	f.EnumVar(&p, name, value, choices, usage, opts...)
+	return &p
+}
+
+// Enum defines an enum flag with specified name, default value, choices, and
+// usage string. The return value is the address of a string variable that
+// stores the canonical value of the choice.
+func Enum(name string, value string, choices []Choice, usage string, opts ...Opt) *string {
+	return CommandLine.Enum(name, value, choices, usage, opts...)
+}
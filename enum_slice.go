@@ -0,0 +1,160 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+// -- enumSlice Value
+type enumSliceValue struct {
+	enumChoices
+	sliceLimits
+	value   *[]string
+	changed bool
+}
+
+func newEnumSliceValue(val []string, choices []Choice, p *[]string) *enumSliceValue {
+	e := &enumSliceValue{enumChoices: enumChoices{choices: choices}, value: p}
+	*e.value = val
+	return e
+}
+
+func (e *enumSliceValue) choiceSet() *enumChoices {
+	return &e.enumChoices
+}
+
+// canonicalize replaces the current values with their canonical choices.
+func (e *enumSliceValue) canonicalize() error {
+	v, err := e.canonicalSlice(*e.value)
+	if err != nil {
+		return err
+	}
+	*e.value = v
+	return nil
+}
+
+func (e *enumSliceValue) canonicalSlice(val []string) ([]string, error) {
+	out := make([]string, len(val))
+	for i, d := range val {
+		var err error
+		if out[i], err = e.canonical(d); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (e *enumSliceValue) Set(val string) error {
+	v, err := readAsCSV(val)
+	if err != nil {
+		return err
+	}
+	v, err = e.canonicalSlice(v)
+	if err != nil {
+		return err
+	}
+	if !e.changed {
+		*e.value = v
+	} else {
+		*e.value = append(*e.value, v...)
+	}
+	e.changed = true
+	return nil
+}
+
+func (e *enumSliceValue) Get() interface{} {
+	return *e.value
+}
+
+func (e *enumSliceValue) Type() string {
+	return "enumSlice"
+}
+
+func (e *enumSliceValue) String() string {
+	str, _ := writeAsCSV(*e.value)
+	return "[" + str + "]"
+}
+
+func (e *enumSliceValue) Append(val string) error {
+	return e.limitAppend(e, val)
+}
+
+func (e *enumSliceValue) Replace(val []string) error {
+	return e.limitReplace(e, val)
+}
+
+func (e *enumSliceValue) appendValue(val string) error {
+	v, err := e.canonical(val)
+	if err != nil {
+		return err
+	}
+	*e.value = append(*e.value, v)
+	return nil
+}
+
+func (e *enumSliceValue) replaceValues(val []string) error {
+	v, err := e.canonicalSlice(val)
+	if err != nil {
+		return err
+	}
+	*e.value = v
+	return nil
+}
+
+func (e *enumSliceValue) GetSlice() []string {
+	return *e.value
+}
+
+func (e *enumSliceValue) snapshot() func() {
+	value, changed := append((*e.value)[:0:0], *e.value...), e.changed
+	return func() { *e.value, e.changed = value, changed }
+}
+
+// GetEnumSlice return the canonical choices of an enum slice flag with the given name
+func (f *FlagSet) GetEnumSlice(name string) ([]string, error) {
+	val, err := f.getFlagType(name, "enumSlice")
+	if err != nil {
+		return []string{}, err
+	}
+	return val.([]string), nil
+}
+
+// MustGetEnumSlice is like GetEnumSlice, but panics on error.
+func (f *FlagSet) MustGetEnumSlice(name string) []string {
+	val, err := f.GetEnumSlice(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// EnumSliceVar defines an enum slice flag with specified name, default value,
+// choices, and usage string. The argument p points to a []string variable in
+// which to store the canonical values of the choices. Like StringSlice flags,
+// it takes comma-separated values, each of which must be a choice, see EnumVar.
+func (f *FlagSet) EnumSliceVar(p *[]string, name string, value []string, choices []Choice, usage string, opts ...Opt) {
+	e := newEnumSliceValue(value, choices, p)
+	f.Var(e, name, usage, enumOpts(&e.enumChoices, opts)...)
+}
+
+// EnumSliceVar defines an enum slice flag with specified name, default value,
+// choices, and usage string. The argument p points to a []string variable in
+// which to store the canonical values of the choices. Like StringSlice flags,
+// it takes comma-separated values, each of which must be a choice, see EnumVar.
+func EnumSliceVar(p *[]string, name string, value []string, choices []Choice, usage string, opts ...Opt) {
+	CommandLine.EnumSliceVar(p, name, value, choices, usage, opts...)
+}
+
+// EnumSlice defines an enum slice flag with specified name, default value,
+// choices, and usage string. The return value is the address of a []string
+// variable that stores the canonical values of the choices.
+func (f *FlagSet) EnumSlice(name string, value []string, choices []Choice, usage string, opts ...Opt) *[]string {
+	var p []string
+	f.EnumSliceVar(&p, name, value, choices, usage, opts...)
+	return &p
+}
+
+// EnumSlice defines an enum slice flag with specified name, default value,
+// choices, and usage string. The return value is the address of a []string
+// variable that stores the canonical values of the choices.
+func EnumSlice(name string, value []string, choices []Choice, usage string, opts ...Opt) *[]string {
+	return CommandLine.EnumSlice(name, value, choices, usage, opts...)
+}
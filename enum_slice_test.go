@@ -0,0 +1,84 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func setUpEnumSliceFlagSet(formats *[]string) *FlagSet {
+	f := NewFlagSet("test", ContinueOnError)
+	f.EnumSliceVar(formats, "formats", []string{"text"}, formatChoices, "output formats")
+	f.SetErrorPresentation(ErrorSilent)
+	return f
+}
+
+func TestEnumSlice(t *testing.T) {
+	var formats []string
+	f := setUpEnumSliceFlagSet(&formats)
+	if !reflect.DeepEqual(formats, []string{"text"}) {
+		t.Fatalf("expected the default value; got %v", formats)
+	}
+
+	if err := f.Parse([]string{"--formats=json,yml", "--formats=text"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"json", "yaml", "text"}
+	if !reflect.DeepEqual(formats, expected) {
+		t.Errorf("expected %v; got %v", expected, formats)
+	}
+	if v := f.MustGetEnumSlice("formats"); !reflect.DeepEqual(v, expected) {
+		t.Errorf("expected the getter to return %v; got %v", expected, v)
+	}
+}
+
+func TestEnumSliceInvalid(t *testing.T) {
+	var formats []string
+	f := setUpEnumSliceFlagSet(&formats)
+	err := f.Parse([]string{"--formats=json,yamll"})
+	expected := `invalid argument "json,yamll" for "--formats" flag: "yamll" is not one of json|yaml|text, did you mean "yaml"?`
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected %q; got %v", expected, err)
+	}
+}
+
+func TestEnumSliceAppendReplace(t *testing.T) {
+	var formats []string
+	f := setUpEnumSliceFlagSet(&formats)
+	v := f.Lookup("formats").Value.(SliceValue)
+
+	if err := v.Append("yml"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Append("xml"); err == nil {
+		t.Error("expected Append to reject values that are not a choice")
+	}
+	if !reflect.DeepEqual(v.GetSlice(), []string{"text", "yaml"}) {
+		t.Errorf("unexpected value %v", v.GetSlice())
+	}
+
+	if err := v.Replace([]string{"json", "yml"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Replace([]string{"xml"}); err == nil {
+		t.Error("expected Replace to reject values that are not a choice")
+	}
+	if !reflect.DeepEqual(formats, []string{"json", "yaml"}) {
+		t.Errorf("unexpected value %v", formats)
+	}
+}
+
+func TestEnumSliceUsage(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.EnumSlice("formats", nil, Choices("json", "yaml"), "output formats")
+
+	expected := "      --formats {json|yaml}   output formats\n"
+	if usage := f.FlagUsages(); usage != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, usage)
+	}
+	if f.Lookup("formats").LongUsage != "" {
+		t.Error("expected no long usage without aliases or descriptions")
+	}
+}
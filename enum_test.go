@@ -0,0 +1,171 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"reflect"
+	"testing"
+)
+
+var formatChoices = []Choice{
+	{Value: "json", Description: "one JSON document"},
+	{Value: "yaml", Aliases: []string{"yml"}},
+	{Value: "text", Description: "human readable"},
+}
+
+func setUpEnumFlagSet(format *string, opts ...Opt) *FlagSet {
+	f := NewFlagSet("test", ContinueOnError)
+	f.EnumVar(format, "format", "text", formatChoices, "output format", opts...)
+	f.SetErrorPresentation(ErrorSilent)
+	return f
+}
+
+func TestEnum(t *testing.T) {
+	var format string
+	f := setUpEnumFlagSet(&format)
+	if format != "text" {
+		t.Fatalf("expected the default value; got %q", format)
+	}
+
+	tests := []struct {
+		arg      string
+		expected string
+	}{
+		{"json", "json"},
+		{"yaml", "yaml"},
+		{"yml", "yaml"},
+	}
+	for _, test := range tests {
+		if err := f.Parse([]string{"--format=" + test.arg}); err != nil {
+			t.Errorf("%s: %v", test.arg, err)
+			continue
+		}
+		if format != test.expected {
+			t.Errorf("%s: expected %q; got %q", test.arg, test.expected, format)
+		}
+		if v := f.MustGetEnum("format"); v != test.expected {
+			t.Errorf("%s: expected the getter to return %q; got %q", test.arg, test.expected, v)
+		}
+	}
+}
+
+func TestEnumInvalid(t *testing.T) {
+	var format string
+	f := setUpEnumFlagSet(&format)
+
+	tests := []struct {
+		arg      string
+		expected string
+	}{
+		{"jsno", `invalid argument "jsno" for "--format" flag: "jsno" is not one of json|yaml|text, did you mean "json"?`},
+		{"ymal", `invalid argument "ymal" for "--format" flag: "ymal" is not one of json|yaml|text, did you mean "yaml"?`},
+		{"csv", `invalid argument "csv" for "--format" flag: "csv" is not one of json|yaml|text`},
+		{"JSON", `invalid argument "JSON" for "--format" flag: "JSON" is not one of json|yaml|text`},
+	}
+	for _, test := range tests {
+		err := f.Parse([]string{"--format=" + test.arg})
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("%s: expected %q; got %v", test.arg, test.expected, err)
+		}
+	}
+}
+
+func TestEnumCaseInsensitive(t *testing.T) {
+	var format string
+	f := setUpEnumFlagSet(&format, OptCaseInsensitive())
+	for _, arg := range []string{"JSON", "Json"} {
+		if err := f.Parse([]string{"--format=" + arg}); err != nil {
+			t.Errorf("%s: %v", arg, err)
+		}
+		if format != "json" {
+			t.Errorf("%s: expected the canonical choice; got %q", arg, format)
+		}
+	}
+	if err := f.Parse([]string{"--format=YML"}); err != nil || format != "yaml" {
+		t.Errorf("expected aliases to match regardless of case; got %q, %v", format, err)
+	}
+	err := f.Parse([]string{"--format=JSNO"})
+	if err == nil || err.Error() != `invalid argument "JSNO" for "--format" flag: "JSNO" is not one of json|yaml|text, did you mean "json"?` {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a flag that is not an enum")
+		}
+	}()
+	f.String("name", "", "name", OptCaseInsensitive())
+}
+
+func TestEnumHelp(t *testing.T) {
+	var format string
+	f := setUpEnumFlagSet(&format)
+
+	expected := "      --format {json|yaml|text}   output format (default text)\n"
+	if usage := f.FlagUsages(); usage != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, usage)
+	}
+
+	flag := f.Lookup("format")
+	if choices := flag.Annotations[EnumChoicesAnnotation]; !reflect.DeepEqual(choices, []string{"json", "yaml", "text"}) {
+		t.Errorf("expected the choices in the annotations; got %v", choices)
+	}
+	expectedLongUsage := "json: one JSON document\nyaml (yml)\ntext: human readable"
+	if flag.LongUsage != expectedLongUsage {
+		t.Errorf("expected long usage %q; got %q", expectedLongUsage, flag.LongUsage)
+	}
+	if len(flag.Choices()) != 3 {
+		t.Errorf("unexpected choices %v", flag.Choices())
+	}
+}
+
+func TestEnumInvalidDefault(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a default value that is not a choice")
+		}
+	}()
+	f := NewFlagSet("test", ContinueOnError)
+	f.Enum("format", "xml", formatChoices, "output format")
+}
+
+func TestEnumCaseInsensitiveDefault(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	format := f.Enum("format", "JSON", Choices("json", "yaml"), "output format", OptCaseInsensitive())
+	if *format != "json" || f.Lookup("format").DefValue != "json" {
+		t.Errorf("expected the canonical default; got %q", *format)
+	}
+
+	kinds := f.EnumSlice("kinds", []string{"POD"}, Choices("pod", "svc"), "kinds", OptCaseInsensitive())
+	if len(*kinds) != 1 || (*kinds)[0] != "pod" {
+		t.Errorf("expected the canonical default; got %v", *kinds)
+	}
+}
+
+func TestEnumAmbiguousChoices(t *testing.T) {
+	tests := []struct {
+		choices  []Choice
+		opts     []Opt
+		expected string
+	}{
+		{Choices("json", "json"), nil, `flag "format": duplicate choice "json"`},
+		{Choices("json", "JSON"), []Opt{OptCaseInsensitive()}, `flag "format": duplicate choice "JSON"`},
+		{[]Choice{{Value: "json"}, {Value: "yaml", Aliases: []string{"json"}}}, nil, `flag "format": alias "json" of choice "yaml" collides with choice "json"`},
+	}
+
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if err, ok := recover().(error); !ok || err.Error() != test.expected {
+					t.Errorf("expected a panic with %q; got %v", test.expected, err)
+				}
+			}()
+			f := NewFlagSet("test", ContinueOnError)
+			f.Enum("format", "", test.choices, "output format", test.opts...)
+		}()
+	}
+
+	f := NewFlagSet("test", ContinueOnError)
+	f.Enum("format", "", Choices("json", "JSON"), "output format")
+}
@@ -0,0 +1,97 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+)
+
+// ErrorPresentation defines what is printed to the FlagSet's output when
+// parsing fails, see FlagSet.SetErrorPresentation.
+type ErrorPresentation int
+
+const (
+	// ErrorShowUsage prints the usage message followed by the error. This is
+	// the default.
+	ErrorShowUsage ErrorPresentation = iota
+	// ErrorShowHint prints the error followed by a hint on how to get the
+	// usage message, e.g. "Run 'tool --help' for usage."
+	ErrorShowHint
+	// ErrorShowFlagHelp prints the error followed by the detailed help of the
+	// offending flag, see FlagHelp. Errors that are not about a defined flag
+	// are presented like ErrorShowHint.
+	ErrorShowFlagHelp
+	// ErrorSilent prints nothing, leaving the presentation to the caller.
+	ErrorSilent
+)
+
+// SetErrorPresentation sets how parse errors of the given categories are
+// presented. Without categories it sets the presentation of all categories
+// that have not been set individually.
+func (f *FlagSet) SetErrorPresentation(p ErrorPresentation, categories ...ErrorCategory) {
+	if len(categories) == 0 {
+		f.errorPresentation = p
+		return
+	}
+	if f.errorPresentations == nil {
+		f.errorPresentations = make(map[ErrorCategory]ErrorPresentation)
+	}
+	for _, category := range categories {
+		f.errorPresentations[category] = p
+	}
+}
+
+// ErrorPresentationFor returns how parse errors of the category are presented.
+func (f *FlagSet) ErrorPresentationFor(category ErrorCategory) ErrorPresentation {
+	if p, ok := f.errorPresentations[category]; ok {
+		return p
+	}
+	return f.errorPresentation
+}
+
+// usageHint returns the hint printed by ErrorShowHint, or "" if there is no
+// help flag to point to.
+func (f *FlagSet) usageHint() string {
+	if f.DisableBuiltinHelp && f.Lookup("help") == nil {
+		return ""
+	}
+	if f.name == "" {
+		return "Run with --help for usage."
+	}
+	return fmt.Sprintf("Run '%s --help' for usage.", f.name)
+}
+
+// fail prints err to the FlagSet's output as configured for its category and
+// returns it.
+func (f *FlagSet) fail(err *ParseError) error {
+	out := f.Output()
+
+	p := f.ErrorPresentationFor(err.Category)
+	err.printed = p != ErrorSilent
+
+	switch {
+	case p == ErrorSilent:
+	case p == ErrorShowFlagHelp && err.Flag != nil && !f.isHiddenFlag(err.Flag):
+		fmt.Fprintln(out, err)
+		if help, herr := f.FlagHelp(err.Flag.Name, 0); herr == nil {
+			fmt.Fprintln(out)
+			fmt.Fprint(out, help)
+		}
+	case p == ErrorShowHint || p == ErrorShowFlagHelp:
+		fmt.Fprintln(out, err)
+		if hint := f.usageHint(); hint != "" {
+			fmt.Fprintln(out, hint)
+		}
+	default:
+		f.usage()
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, err)
+	}
+	return err
+}
+
+// failf is like fail, with the underlying error formatted like fmt.Errorf.
+func (f *FlagSet) failf(category ErrorCategory, flag *Flag, format string, a ...interface{}) error {
+	return f.fail(&ParseError{Category: category, Flag: flag, Err: fmt.Errorf(format, a...)})
+}
@@ -0,0 +1,123 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestErrorPresentation(t *testing.T) {
+	newFlagSet := func(p ErrorPresentation) (*FlagSet, *bytes.Buffer) {
+		fs := NewFlagSet("test", ContinueOnError)
+		fs.Int("port", 80, "port to listen on", OptShorthand('p'))
+		fs.SetErrorPresentation(p)
+		buf := new(bytes.Buffer)
+		fs.SetOutput(buf)
+		return fs, buf
+	}
+
+	fs, buf := newFlagSet(ErrorShowUsage)
+	_ = fs.Parse([]string{"--unknown"})
+	if !strings.HasPrefix(buf.String(), "Usage: test") || !strings.HasSuffix(buf.String(), "\nunknown flag: --unknown\n") {
+		t.Errorf("expected usage and error; got %q", buf.String())
+	}
+
+	fs, buf = newFlagSet(ErrorShowHint)
+	_ = fs.Parse([]string{"--unknown"})
+	if expected := "unknown flag: --unknown\nRun 'test --help' for usage.\n"; buf.String() != expected {
+		t.Errorf("expected %q; got %q", expected, buf.String())
+	}
+
+	fs, buf = newFlagSet(ErrorShowFlagHelp)
+	_ = fs.Parse([]string{"-p", "http"})
+	help, _ := fs.FlagHelp("port", 0)
+	expected := "invalid argument \"http\" for \"-p, --port\" flag: strconv.ParseInt: parsing \"http\": invalid syntax\n\n" + help
+	if buf.String() != expected {
+		t.Errorf("expected %q; got %q", expected, buf.String())
+	}
+
+	fs, buf = newFlagSet(ErrorShowFlagHelp)
+	_ = fs.Parse([]string{"-x"})
+	if expected := "unknown shorthand flag: 'x' in -x\nRun 'test --help' for usage.\n"; buf.String() != expected {
+		t.Errorf("expected %q; got %q", expected, buf.String())
+	}
+
+	fs, buf = newFlagSet(ErrorSilent)
+	if err := fs.Parse([]string{"--port"}); err == nil {
+		t.Error("expected an error")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output; got %q", buf.String())
+	}
+}
+
+func TestErrorPresentationPerCategory(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.Int("port", 80, "port to listen on")
+	fs.SetErrorPresentation(ErrorShowHint)
+	fs.SetErrorPresentation(ErrorSilent, ErrorInvalidValue)
+	buf := new(bytes.Buffer)
+	fs.SetOutput(buf)
+
+	if fs.ErrorPresentationFor(ErrorUnknownFlag) != ErrorShowHint || fs.ErrorPresentationFor(ErrorInvalidValue) != ErrorSilent {
+		t.Fatal("unexpected error presentations")
+	}
+
+	err := fs.Parse([]string{"--port=http"})
+	if err == nil || buf.Len() != 0 {
+		t.Errorf("expected a silent error; got %v and %q", err, buf.String())
+	}
+
+	fs.DisableBuiltinHelp = true
+	_ = fs.Parse([]string{"--unknown"})
+	if expected := "unknown flag: --unknown\n"; buf.String() != expected {
+		t.Errorf("expected no hint without help flag; got %q", buf.String())
+	}
+}
+
+func TestParseErrorCategories(t *testing.T) {
+	tests := []struct {
+		args     []string
+		category ErrorCategory
+		flag     string
+	}{
+		{[]string{"---bad"}, ErrorSyntax, ""},
+		{[]string{"--unknown"}, ErrorUnknownFlag, ""},
+		{[]string{"-x"}, ErrorUnknownFlag, ""},
+		{[]string{"--port"}, ErrorMissingArgument, "port"},
+		{[]string{"--port=http"}, ErrorInvalidValue, "port"},
+	}
+
+	for _, test := range tests {
+		fs := NewFlagSet("test", ContinueOnError)
+		fs.Int("port", 80, "port to listen on")
+		fs.SetErrorPresentation(ErrorSilent)
+
+		err := fs.Parse(test.args)
+		if ErrorCategoryOf(err) != test.category {
+			t.Errorf("%v: expected category %v; got %v", test.args, test.category, ErrorCategoryOf(err))
+		}
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("%v: expected a ParseError; got %T", test.args, err)
+		}
+		if (parseErr.Flag == nil && test.flag != "") || (parseErr.Flag != nil && parseErr.Flag.Name != test.flag) {
+			t.Errorf("%v: expected flag %q; got %v", test.args, test.flag, parseErr.Flag)
+		}
+	}
+
+	if ErrorCategoryOf(errors.New("other")) != ErrorOther {
+		t.Error("expected ErrorOther for other errors")
+	}
+
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetErrorPresentation(ErrorSilent)
+	err := fs.Parse([]string{"--unknown"})
+	if !errors.Is(err, NewUnknownFlagError("unknown")) {
+		t.Errorf("expected the unknown flag error to be wrapped; got %v", err)
+	}
+}
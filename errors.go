@@ -3,7 +3,10 @@
 
 package zflag
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
 
 type errUnknownFlag struct {
 	name string
@@ -21,3 +24,87 @@ func (e errUnknownFlag) Error() string {
 
 	return fmt.Sprintf("unknown flag: %s", dash+e.name)
 }
+
+// ErrorCategory classifies the errors returned by parsing, see ParseError.
+type ErrorCategory int
+
+const (
+	// ErrorOther is the category of errors that do not fit another category.
+	ErrorOther ErrorCategory = iota
+	// ErrorSyntax is the category of malformed arguments, e.g. "---flag".
+	ErrorSyntax
+	// ErrorUnknownFlag is the category of flags that are not defined.
+	ErrorUnknownFlag
+	// ErrorMissingArgument is the category of flags given without their
+	// required argument.
+	ErrorMissingArgument
+	// ErrorInvalidValue is the category of values rejected by a flag.
+	ErrorInvalidValue
+	// ErrorWarning is the category of warnings turned into errors by the
+	// WarningHandler.
+	ErrorWarning
+	// ErrorRemovedFlag is the category of deprecated flags used after they
+	// were removed, see FlagSet.StrictDeprecation.
+	ErrorRemovedFlag
+	// ErrorExperimentalFlag is the category of experimental flags used while
+	// they are not enabled, see FlagSet.SetExperimentalGate.
+	ErrorExperimentalFlag
+)
+
+var errorCategoryNames = []string{"other", "syntax", "unknown flag", "missing argument", "invalid value", "warning", "removed flag", "experimental flag"}
+
+func (c ErrorCategory) String() string {
+	if c >= 0 && int(c) < len(errorCategoryNames) {
+		return errorCategoryNames[c]
+	}
+	return fmt.Sprintf("ErrorCategory(%d)", int(c))
+}
+
+// ParseError is the error returned when parsing fails. It wraps the
+// underlying error, e.g. the error returned by Value.Set.
+type ParseError struct {
+	// Category classifies the error.
+	Category ErrorCategory
+	// Flag is the flag the error is about, nil for unknown flags.
+	Flag *Flag
+	// Err is the underlying error.
+	Err error
+
+	printed bool
+}
+
+func (e *ParseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// valueErrorCategory returns the category of an error returned when setting
+// the value of a flag.
+func valueErrorCategory(err error) ErrorCategory {
+	var warning *Warning
+	if errors.As(err, &warning) {
+		return ErrorWarning
+	}
+	var removed errFlagRemoved
+	if errors.As(err, &removed) {
+		return ErrorRemovedFlag
+	}
+	var experimental errFlagExperimental
+	if errors.As(err, &experimental) {
+		return ErrorExperimentalFlag
+	}
+	return ErrorInvalidValue
+}
+
+// ErrorCategoryOf returns the category of err if it is or wraps a ParseError,
+// and ErrorOther otherwise.
+func ErrorCategoryOf(err error) ErrorCategory {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.Category
+	}
+	return ErrorOther
+}
@@ -0,0 +1,60 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"os"
+)
+
+// SetExitCode sets the code the program exits with under ExitOnError when
+// parsing fails with an error of one of the categories. Without categories it
+// sets the code of all categories that have not been set individually, which
+// defaults to 2. For example, to exit with the sysexits EX_USAGE code:
+//
+//	flagSet.SetExitCode(64)
+func (f *FlagSet) SetExitCode(code int, categories ...ErrorCategory) {
+	if len(categories) == 0 {
+		f.exitCode = code
+		f.exitCodeSet = true
+		return
+	}
+	if f.exitCodes == nil {
+		f.exitCodes = make(map[ErrorCategory]int)
+	}
+	for _, category := range categories {
+		f.exitCodes[category] = code
+	}
+}
+
+// ExitCode returns the code the program exits with under ExitOnError when
+// parsing fails with err. It is 0 for ErrHelp and ErrVersion.
+func (f *FlagSet) ExitCode(err error) int {
+	if err == ErrHelp || err == ErrVersion {
+		return 0
+	}
+	if code, ok := f.exitCodes[ErrorCategoryOf(err)]; ok {
+		return code
+	}
+	if f.exitCodeSet {
+		return f.exitCode
+	}
+	return 2
+}
+
+// exit ends the program for err under ExitOnError, using the ExitFunc.
+func (f *FlagSet) exit(err error) {
+	code := f.ExitCode(err)
+	if code != 0 && f.PrintErrorOnExit {
+		if parseErr, ok := err.(*ParseError); !ok || !parseErr.printed {
+			fmt.Fprintln(f.Output(), err)
+		}
+	}
+
+	if f.ExitFunc != nil {
+		f.ExitFunc(code)
+		return
+	}
+	os.Exit(code)
+}
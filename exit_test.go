@@ -0,0 +1,78 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestExitFunc(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{"--help"}, 0},
+		{[]string{"--version"}, 0},
+		{[]string{"--unknown"}, 64},
+		{[]string{"--port=http"}, 65},
+		{[]string{"--port"}, 64},
+	}
+
+	for _, test := range tests {
+		fs := NewFlagSet("test", ExitOnError)
+		fs.Int("port", 80, "port to listen on")
+		fs.SetVersion(VersionInfo{Version: "v1.0.0"})
+		fs.SetExitCode(64)
+		fs.SetExitCode(65, ErrorInvalidValue)
+		fs.SetOutput(new(bytes.Buffer))
+
+		code := -1
+		fs.ExitFunc = func(c int) { code = c }
+		if err := fs.Parse(test.args); err == nil {
+			t.Errorf("%v: expected the error to be returned", test.args)
+		}
+		if code != test.code {
+			t.Errorf("%v: expected exit code %d; got %d", test.args, test.code, code)
+		}
+	}
+}
+
+func TestExitCodeDefault(t *testing.T) {
+	fs := NewFlagSet("test", ExitOnError)
+	if code := fs.ExitCode(NewUnknownFlagError("x")); code != 2 {
+		t.Errorf("expected exit code 2; got %d", code)
+	}
+	if code := fs.ExitCode(ErrHelp); code != 0 {
+		t.Errorf("expected exit code 0; got %d", code)
+	}
+}
+
+func TestPrintErrorOnExit(t *testing.T) {
+	fs := NewFlagSet("test", ExitOnError)
+	fs.SetErrorPresentation(ErrorSilent)
+	fs.PrintErrorOnExit = true
+	fs.ExitFunc = func(int) {}
+	buf := new(bytes.Buffer)
+	fs.SetOutput(buf)
+
+	_ = fs.Parse([]string{"--unknown"})
+	if buf.String() != "unknown flag: --unknown\n" {
+		t.Errorf("expected the error to be printed; got %q", buf.String())
+	}
+
+	buf.Reset()
+	fs.SetErrorPresentation(ErrorShowHint)
+	_ = fs.Parse([]string{"--unknown"})
+	if expected := "unknown flag: --unknown\nRun 'test --help' for usage.\n"; buf.String() != expected {
+		t.Errorf("expected the error to be printed once; got %q", buf.String())
+	}
+
+	buf.Reset()
+	fs.exit(errors.New("custom error"))
+	if buf.String() != "custom error\n" {
+		t.Errorf("expected the error to be printed; got %q", buf.String())
+	}
+}
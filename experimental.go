@@ -0,0 +1,222 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Stage is the maturity of an experimental feature, see OptExperimental.
+type Stage string
+
+const (
+	// StageAlpha features may change or be removed without notice.
+	StageAlpha Stage = "alpha"
+	// StageBeta features are well tested, but may still change.
+	StageBeta Stage = "beta"
+	// StageGA features are generally available, see FeatureGates.
+	StageGA Stage = "ga"
+	// StageDeprecated features are going to be removed, see FeatureGates.
+	StageDeprecated Stage = "deprecated"
+)
+
+const (
+	// DefaultExperimentalFlag is the name of the built-in flag that enables
+	// experimental flags, see SetExperimentalGate.
+	DefaultExperimentalFlag = "enable-experimental"
+	// DefaultExperimentalEnv is the environment variable that enables
+	// experimental flags, see SetExperimentalGate.
+	DefaultExperimentalEnv = "APP_EXPERIMENTAL"
+)
+
+// ExperimentalFormatter is an optional interface for a FlagUsageFormatter to
+// format the stage of experimental flags.
+type ExperimentalFormatter interface {
+	Experimental(*Flag) string
+}
+
+// experimentalNote returns the stage of flag formatted by usageFormatter.
+func experimentalNote(usageFormatter FlagUsageFormatter, flag *Flag) string {
+	if e, ok := usageFormatter.(ExperimentalFormatter); ok {
+		return e.Experimental(flag)
+	}
+	return DefaultFlagUsageFormatter{}.Experimental(flag)
+}
+
+// SetExperimentalGate configures how experimental flags are enabled: with the
+// built-in flag --<flagName> or by setting the environment variable envVar to
+// a true value such as "1". Either can be empty to disable it. The defaults are
+// DefaultExperimentalFlag and DefaultExperimentalEnv.
+func (f *FlagSet) SetExperimentalGate(flagName, envVar string) {
+	f.experimentalFlag = flagName
+	f.experimentalEnv = envVar
+	f.experimentalGateSet = true
+}
+
+// experimentalGate returns the name of the built-in flag and the environment
+// variable that enable experimental flags. Both are empty unless the flag set
+// has experimental flags or SetExperimentalGate was called.
+func (f *FlagSet) experimentalGate() (string, string) {
+	if f.experimentalGateSet {
+		return f.experimentalFlag, f.experimentalEnv
+	}
+	for _, flag := range f.formal {
+		if flag.Experimental != "" {
+			return DefaultExperimentalFlag, DefaultExperimentalEnv
+		}
+	}
+	return "", ""
+}
+
+// ExperimentalEnabled reports whether experimental flags are enabled, by
+// EnableExperimental, the built-in flag or the environment variable.
+func (f *FlagSet) ExperimentalEnabled() bool {
+	if f.EnableExperimental || f.experimentalRequested {
+		return true
+	}
+	if _, env := f.experimentalGate(); env != "" {
+		enabled, _ := strconv.ParseBool(os.Getenv(env))
+		return enabled
+	}
+	return false
+}
+
+// scanExperimentalGate looks for the built-in flag enabling experimental flags
+// in the arguments, so it applies regardless of its position. Like parsing, it
+// stops at "--", and at the first non-flag argument without interspersed
+// arguments. It returns an error if the flag or the environment variable is
+// not a boolean.
+func (f *FlagSet) scanExperimentalGate(arguments []string) error {
+	f.experimentalRequested = false
+	name, env := f.experimentalGate()
+	if value := os.Getenv(env); env != "" && value != "" {
+		if _, err := strconv.ParseBool(value); err != nil {
+			return f.failf(ErrorInvalidValue, nil, "invalid value %q for environment variable %s: %v", value, env, err)
+		}
+	}
+	if name == "" || f.Lookup(name) != nil {
+		return nil
+	}
+
+	for i := 0; i < len(arguments); i++ {
+		arg := arguments[i]
+		if arg == "--" {
+			return nil
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			if !f.interspersed {
+				return nil
+			}
+			continue
+		}
+		if !strings.HasPrefix(arg, "--"+name) {
+			if f.needsArgument(arg) {
+				// skip the value of '--flag arg' or '-f arg'
+				i++
+			}
+			continue
+		}
+		switch value := arg[len(name)+2:]; {
+		case value == "":
+			f.experimentalRequested = true
+		case value[0] == '=':
+			enabled, err := strconv.ParseBool(value[1:])
+			if err != nil {
+				return f.failf(ErrorInvalidValue, nil, "invalid argument %q for %q flag: %v", value[1:], "--"+name, err)
+			}
+			f.experimentalRequested = enabled
+		}
+	}
+	return nil
+}
+
+// needsArgument reports whether the flag argument arg, e.g. "--flag" or "-vf",
+// takes the next argument as its value.
+func (f *FlagSet) needsArgument(arg string) bool {
+	if strings.HasPrefix(arg, "--") {
+		if strings.Contains(arg, "=") {
+			return false
+		}
+		flag := f.Lookup(arg[2:])
+		return flag != nil && flag.NoOptDefVal == ""
+	}
+
+	shorthands := []rune(arg[1:])
+	for i, c := range shorthands {
+		flag := f.ShorthandLookup(c)
+		if flag == nil {
+			return false
+		}
+		if flag.NoOptDefVal == "" {
+			return i == len(shorthands)-1
+		}
+	}
+	return false
+}
+
+// isExperimentalGateFlag reports whether name is the built-in flag enabling
+// experimental flags.
+func (f *FlagSet) isExperimentalGateFlag(name string) bool {
+	gate, _ := f.experimentalGate()
+	return gate != "" && name == gate
+}
+
+// isGatedFlag reports whether flag is experimental and experimental flags are
+// not enabled.
+func (f *FlagSet) isGatedFlag(flag *Flag) bool {
+	return flag.Experimental != "" && !f.ExperimentalEnabled()
+}
+
+// experimentalHow returns the ways to enable experimental flags, e.g.
+// "--enable-experimental or APP_EXPERIMENTAL=1".
+func (f *FlagSet) experimentalHow() string {
+	var how []string
+	name, env := f.experimentalGate()
+	if name != "" {
+		how = append(how, "--"+name)
+	}
+	if env != "" {
+		how = append(how, env+"=1")
+	}
+	return strings.Join(how, " or ")
+}
+
+// experimentalFooter returns the note printed below the usage message when n
+// experimental flags are left out because they are not enabled.
+func (f *FlagSet) experimentalFooter(n int) string {
+	how := f.experimentalHow()
+	switch {
+	case n == 0 || how == "":
+		return ""
+	case n == 1:
+		return fmt.Sprintf("1 experimental flag is not shown, enable it with %s.", how)
+	}
+	return fmt.Sprintf("%d experimental flags are not shown, enable them with %s.", n, how)
+}
+
+// errFlagExperimental is returned when an experimental flag is set while
+// experimental flags are not enabled.
+type errFlagExperimental struct {
+	flag *Flag
+	how  string
+}
+
+func (e errFlagExperimental) Error() string {
+	if e.how == "" {
+		return fmt.Sprintf("flag --%s is experimental (%s) and not enabled", e.flag.Name, e.flag.Experimental)
+	}
+	return fmt.Sprintf("flag --%s is experimental (%s), enable it with %s", e.flag.Name, e.flag.Experimental, e.how)
+}
+
+// checkExperimental returns an error if flag is experimental and experimental
+// flags are not enabled.
+func (f *FlagSet) checkExperimental(flag *Flag) error {
+	if !f.isGatedFlag(flag) {
+		return nil
+	}
+	return errFlagExperimental{flag: flag, how: f.experimentalHow()}
+}
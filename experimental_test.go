@@ -0,0 +1,235 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+// restoreEnv restores the environment variable name when the test ends.
+func restoreEnv(t *testing.T, name string) {
+	previous, ok := os.LookupEnv(name)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(name, previous)
+		} else {
+			os.Unsetenv(name)
+		}
+	})
+}
+
+func setEnv(t *testing.T, name, value string) {
+	restoreEnv(t, name)
+	os.Setenv(name, value)
+}
+
+func newExperimentalFlagSet() (*FlagSet, *bytes.Buffer) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.Bool("verbose", false, "verbose output")
+	fs.Bool("turbo", false, "go faster", OptExperimental(StageAlpha))
+	fs.SetErrorPresentation(ErrorShowHint)
+	buf := new(bytes.Buffer)
+	fs.SetOutput(buf)
+	return fs, buf
+}
+
+func TestExperimentalGateClosed(t *testing.T) {
+	restoreEnv(t, DefaultExperimentalEnv)
+	os.Unsetenv(DefaultExperimentalEnv)
+
+	fs, buf := newExperimentalFlagSet()
+	err := fs.Parse([]string{"--turbo"})
+	if ErrorCategoryOf(err) != ErrorExperimentalFlag {
+		t.Fatalf("expected an experimental flag error; got %v", err)
+	}
+	expected := "flag --turbo is experimental (alpha), enable it with --enable-experimental or APP_EXPERIMENTAL=1\nRun 'test --help' for usage.\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q; got %q", expected, buf.String())
+	}
+
+	if usage := fs.FlagUsages(); strings.Contains(usage, "turbo") {
+		t.Errorf("expected the experimental flag to be hidden; got %q", usage)
+	}
+}
+
+func TestExperimentalGateFlag(t *testing.T) {
+	restoreEnv(t, DefaultExperimentalEnv)
+	os.Unsetenv(DefaultExperimentalEnv)
+
+	for _, args := range [][]string{
+		{"--enable-experimental", "--turbo"},
+		{"--turbo", "--enable-experimental"},
+		{"--turbo", "--enable-experimental=true"},
+	} {
+		fs, _ := newExperimentalFlagSet()
+		if err := fs.Parse(args); err != nil {
+			t.Errorf("%v: %v", args, err)
+			continue
+		}
+		if !fs.MustGetBool("turbo") {
+			t.Errorf("%v: expected the experimental flag to be set", args)
+		}
+		if len(fs.Args()) != 0 {
+			t.Errorf("%v: expected no arguments; got %v", args, fs.Args())
+		}
+	}
+
+	fs, _ := newExperimentalFlagSet()
+	if err := fs.Parse([]string{"--", "--enable-experimental"}); err != nil {
+		t.Fatal(err)
+	}
+	if fs.ExperimentalEnabled() {
+		t.Error("expected arguments after -- to be ignored")
+	}
+}
+
+func TestExperimentalGateInvalid(t *testing.T) {
+	restoreEnv(t, DefaultExperimentalEnv)
+	os.Unsetenv(DefaultExperimentalEnv)
+
+	fs, _ := newExperimentalFlagSet()
+	fs.SetErrorPresentation(ErrorSilent)
+	err := fs.Parse([]string{"--enable-experimental=bogus"})
+	expected := `invalid argument "bogus" for "--enable-experimental" flag: strconv.ParseBool: parsing "bogus": invalid syntax`
+	if err == nil || err.Error() != expected || ErrorCategoryOf(err) != ErrorInvalidValue {
+		t.Errorf("expected %q; got %v", expected, err)
+	}
+
+	os.Setenv(DefaultExperimentalEnv, "bogus")
+	fs, _ = newExperimentalFlagSet()
+	fs.SetErrorPresentation(ErrorSilent)
+	err = fs.Parse(nil)
+	expected = `invalid value "bogus" for environment variable APP_EXPERIMENTAL: strconv.ParseBool: parsing "bogus": invalid syntax`
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected %q; got %v", expected, err)
+	}
+}
+
+func TestExperimentalGateWithoutExperimentalFlags(t *testing.T) {
+	setEnv(t, DefaultExperimentalEnv, "bogus")
+
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.Bool("verbose", false, "verbose output")
+	fs.SetErrorPresentation(ErrorSilent)
+	if err := fs.Parse(nil); err != nil {
+		t.Errorf("expected the environment variable to be ignored; got %v", err)
+	}
+	err := fs.Parse([]string{"--enable-experimental"})
+	if err == nil || err.Error() != "unknown flag: --enable-experimental" {
+		t.Errorf("expected an unknown flag error; got %v", err)
+	}
+
+	fs.SetExperimentalGate("enable-experimental", "")
+	if err := fs.Parse([]string{"--enable-experimental"}); err != nil {
+		t.Errorf("expected the gate flag after SetExperimentalGate; got %v", err)
+	}
+}
+
+func TestExperimentalGateEnv(t *testing.T) {
+	setEnv(t, DefaultExperimentalEnv, "1")
+
+	fs, _ := newExperimentalFlagSet()
+	if err := fs.Parse([]string{"--turbo"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "      --turbo     go faster (EXPERIMENTAL: alpha)\n" +
+		"      --verbose   verbose output\n"
+	if usage := fs.FlagUsages(); usage != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, usage)
+	}
+}
+
+func TestSetExperimentalGate(t *testing.T) {
+	setEnv(t, DefaultExperimentalEnv, "1")
+
+	fs, buf := newExperimentalFlagSet()
+	fs.SetExperimentalGate("unstable", "")
+	err := fs.Parse([]string{"--turbo"})
+	if !strings.HasPrefix(buf.String(), "flag --turbo is experimental (alpha), enable it with --unstable\n") {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := fs.Parse([]string{"--enable-experimental"}); ErrorCategoryOf(err) != ErrorUnknownFlag {
+		t.Errorf("expected the default gate flag to be unknown; got %v", err)
+	}
+	if err := fs.Parse([]string{"--unstable", "--turbo"}); err != nil {
+		t.Error(err)
+	}
+
+	fs, _ = newExperimentalFlagSet()
+	fs.SetExperimentalGate("", "")
+	fs.EnableExperimental = true
+	if err := fs.Parse([]string{"--turbo"}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExperimentalGateSet(t *testing.T) {
+	restoreEnv(t, DefaultExperimentalEnv)
+	os.Unsetenv(DefaultExperimentalEnv)
+
+	fs, _ := newExperimentalFlagSet()
+	if err := fs.Set("turbo", "true"); err == nil {
+		t.Error("expected Set to fail for a flag that is not enabled")
+	}
+	if err := fs.SetWithSource("turbo", "true", SourceConfig); err == nil {
+		t.Error("expected SetWithSource to fail for a flag that is not enabled")
+	}
+	if fs.Lookup("turbo").Changed {
+		t.Error("expected the experimental flag not to be set")
+	}
+
+	fs.EnableExperimental = true
+	if err := fs.Set("turbo", "true"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExperimentalGateScan(t *testing.T) {
+	restoreEnv(t, DefaultExperimentalEnv)
+	os.Unsetenv(DefaultExperimentalEnv)
+
+	tests := []struct {
+		args         []string
+		interspersed bool
+		enabled      bool
+	}{
+		{[]string{"--turbo", "cmd", "--enable-experimental"}, true, true},
+		{[]string{"cmd", "--enable-experimental"}, false, false},
+		{[]string{"--message", "--enable-experimental"}, true, false},
+		{[]string{"-m", "--enable-experimental"}, true, false},
+		{[]string{"-vm", "hi", "--enable-experimental"}, true, true},
+		{[]string{"-mhi", "--enable-experimental"}, true, true},
+	}
+
+	for _, test := range tests {
+		fs, _ := newExperimentalFlagSet()
+		fs.Bool("v", false, "", OptShorthandOnly())
+		fs.String("message", "", "message", OptShorthand('m'))
+		fs.SetInterspersed(test.interspersed)
+		fs.scanExperimentalGate(test.args)
+		if fs.ExperimentalEnabled() != test.enabled {
+			t.Errorf("%v: expected enabled %v", test.args, test.enabled)
+		}
+	}
+}
+
+func TestExperimentalFooter(t *testing.T) {
+	restoreEnv(t, DefaultExperimentalEnv)
+	os.Unsetenv(DefaultExperimentalEnv)
+
+	fs, _ := newExperimentalFlagSet()
+	expected := "1 experimental flag is not shown, enable it with --enable-experimental or APP_EXPERIMENTAL=1."
+	if footer := fs.HelpModel().Footer; footer != expected {
+		t.Errorf("expected %q; got %q", expected, footer)
+	}
+
+	fs.EnableExperimental = true
+	if footer := fs.HelpModel().Footer; footer != "" {
+		t.Errorf("expected no footer; got %q", footer)
+	}
+}
@@ -0,0 +1,203 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Feature is the name of a feature gate.
+type Feature string
+
+// FeatureSpec describes a feature gate registered with FeatureGates.Add.
+type FeatureSpec struct {
+	// Default is the state of the gate if it is not set.
+	Default bool
+	// Stage is the maturity of the feature.
+	Stage Stage
+	// LockToDefault prevents the gate from being set to anything but its
+	// default, typically for GA or deprecated features.
+	LockToDefault bool
+}
+
+// FeatureGates is a registry of known feature gates and their state, set with
+// a flag like --feature-gates=A=true,B=false. See FeatureGatesVar.
+type FeatureGates struct {
+	mu      sync.RWMutex
+	known   map[Feature]FeatureSpec
+	enabled map[Feature]bool
+}
+
+// NewFeatureGates returns an empty registry of feature gates.
+func NewFeatureGates() *FeatureGates {
+	return &FeatureGates{
+		known:   make(map[Feature]FeatureSpec),
+		enabled: make(map[Feature]bool),
+	}
+}
+
+// Add registers feature gates. It fails if a gate was already added with a
+// different spec.
+func (g *FeatureGates) Add(features map[Feature]FeatureSpec) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for name, spec := range features {
+		if existing, ok := g.known[name]; ok && existing != spec {
+			return fmt.Errorf("feature gate %q with different spec already exists: %v", name, existing)
+		}
+	}
+	for name, spec := range features {
+		g.known[name] = spec
+	}
+	return nil
+}
+
+// Enabled reports whether the feature gate is enabled. Unknown gates are
+// never enabled.
+func (g *FeatureGates) Enabled(name Feature) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if enabled, ok := g.enabled[name]; ok {
+		return enabled
+	}
+	return g.known[name].Default
+}
+
+// SetFromMap sets the state of feature gates. Unknown gates and locked gates
+// set to a value other than their default are rejected, in which case no gate
+// is changed.
+func (g *FeatureGates) SetFromMap(m map[string]bool) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		enabled := m[name]
+		spec, ok := g.known[Feature(name)]
+		if !ok {
+			return fmt.Errorf("unrecognized feature gate: %s", name)
+		}
+		if spec.LockToDefault && enabled != spec.Default {
+			return fmt.Errorf("cannot set feature gate %v to %v, feature is locked to %v", name, enabled, spec.Default)
+		}
+	}
+	for name, enabled := range m {
+		g.enabled[Feature(name)] = enabled
+	}
+	return nil
+}
+
+// KnownFeatures returns a description of every registered gate with its stage
+// and default, e.g. "NewParser=true|false (ALPHA - default=false)", sorted by
+// name.
+func (g *FeatureGates) KnownFeatures() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	known := make([]string, 0, len(g.known))
+	for name, spec := range g.known {
+		stage := strings.ToUpper(string(spec.Stage))
+		if stage == "" {
+			stage = "UNKNOWN"
+		}
+		if spec.LockToDefault {
+			known = append(known, fmt.Sprintf("%s=%t (%s - locked to default)", name, spec.Default, stage))
+		} else {
+			known = append(known, fmt.Sprintf("%s=true|false (%s - default=%t)", name, stage, spec.Default))
+		}
+	}
+	sort.Strings(known)
+	return known
+}
+
+// Format: A=true,B=false
+func (g *FeatureGates) Set(val string) error {
+	kv, err := readCSVKeyValue(val)
+	if err != nil {
+		return err
+	}
+
+	m := make(map[string]bool, len(kv))
+	for name, value := range kv {
+		name = strings.TrimSpace(name)
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid value of %s=%s, err: %v", name, value, err)
+		}
+		m[name] = enabled
+	}
+	return g.SetFromMap(m)
+}
+
+func (g *FeatureGates) Get() interface{} {
+	return g
+}
+
+func (g *FeatureGates) Type() string {
+	return "featureGates"
+}
+
+func (g *FeatureGates) String() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	pairs := make([]string, 0, len(g.enabled))
+	for name, enabled := range g.enabled {
+		pairs = append(pairs, fmt.Sprintf("%s=%t", name, enabled))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// GetFeatureGates return the FeatureGates of a flag with the given name
+func (f *FlagSet) GetFeatureGates(name string) (*FeatureGates, error) {
+	val, err := f.getFlagType(name, "featureGates")
+	if err != nil {
+		return nil, err
+	}
+	return val.(*FeatureGates), nil
+}
+
+// MustGetFeatureGates is like GetFeatureGates, but panics on error.
+func (f *FlagSet) MustGetFeatureGates(name string) *FeatureGates {
+	val, err := f.GetFeatureGates(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// appendUsage appends the gates known when the usage is shown.
+func (g *FeatureGates) appendUsage(usage string) string {
+	known := g.KnownFeatures()
+	if len(known) == 0 {
+		return usage
+	}
+	return usage + "\nOptions are:\n" + strings.Join(known, "\n")
+}
+
+// FeatureGatesVar defines a feature gates flag with specified name and usage
+// string, setting the gates registered in g. The usage message follows the
+// usage string with the list of gates known when it is shown.
+func (f *FlagSet) FeatureGatesVar(g *FeatureGates, name string, usage string, opts ...Opt) {
+	f.Var(g, name, usage, opts...)
+}
+
+// FeatureGatesVar defines a feature gates flag with specified name and usage
+// string, setting the gates registered in g. The usage message follows the
+// usage string with the list of gates known when it is shown.
+func FeatureGatesVar(g *FeatureGates, name string, usage string, opts ...Opt) {
+	CommandLine.FeatureGatesVar(g, name, usage, opts...)
+}
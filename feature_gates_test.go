@@ -0,0 +1,113 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"strings"
+	"testing"
+)
+
+const (
+	featureNewParser Feature = "NewParser"
+	featureFastPath  Feature = "FastPath"
+	featureLegacyAPI Feature = "LegacyAPI"
+)
+
+func setUpFeatureGates(t *testing.T) (*FlagSet, *FeatureGates) {
+	gates := NewFeatureGates()
+	err := gates.Add(map[Feature]FeatureSpec{
+		featureNewParser: {Default: false, Stage: StageAlpha},
+		featureFastPath:  {Default: true, Stage: StageBeta},
+		featureLegacyAPI: {Default: true, Stage: StageGA, LockToDefault: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.FeatureGatesVar(gates, "feature-gates", "a set of key=value pairs that describe feature gates")
+	fs.SetErrorPresentation(ErrorSilent)
+	return fs, gates
+}
+
+func TestFeatureGates(t *testing.T) {
+	fs, gates := setUpFeatureGates(t)
+	if gates.Enabled(featureNewParser) || !gates.Enabled(featureFastPath) {
+		t.Fatal("expected the defaults before parsing")
+	}
+
+	err := fs.Parse([]string{"--feature-gates=NewParser=true,FastPath=false", "--feature-gates", "LegacyAPI=true"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gates.Enabled(featureNewParser) || gates.Enabled(featureFastPath) || !gates.Enabled(featureLegacyAPI) {
+		t.Errorf("unexpected gates %s", gates)
+	}
+	if gates.Enabled("Unknown") {
+		t.Error("expected unknown gates to be disabled")
+	}
+	if s := gates.String(); s != "FastPath=false,LegacyAPI=true,NewParser=true" {
+		t.Errorf("unexpected string %q", s)
+	}
+	if fs.MustGetFeatureGates("feature-gates") != gates {
+		t.Error("expected the getter to return the gates")
+	}
+}
+
+func TestFeatureGatesErrors(t *testing.T) {
+	tests := []struct {
+		arg      string
+		expected string
+	}{
+		{"Unknown=true", "unrecognized feature gate: Unknown"},
+		{"NewParser=yes", "invalid value of NewParser=yes"},
+		{"LegacyAPI=false", "cannot set feature gate LegacyAPI to false, feature is locked to true"},
+		{"NewParser", "NewParser must be formatted as key=value"},
+	}
+
+	for _, test := range tests {
+		fs, gates := setUpFeatureGates(t)
+		err := fs.Parse([]string{"--feature-gates=FastPath=false," + test.arg})
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("%s: expected error containing %q; got %v", test.arg, test.expected, err)
+		}
+		if !gates.Enabled(featureFastPath) {
+			t.Errorf("%s: expected no gate to be changed", test.arg)
+		}
+	}
+}
+
+func TestFeatureGatesUsage(t *testing.T) {
+	fs, _ := setUpFeatureGates(t)
+
+	expected := `      --feature-gates featureGates   a set of key=value pairs that describe feature gates
+                                     Options are:
+                                     FastPath=true|false (BETA - default=true)
+                                     LegacyAPI=true (GA - locked to default)
+                                     NewParser=true|false (ALPHA - default=false)
+`
+	if usage := fs.FlagUsages(); usage != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, usage)
+	}
+}
+
+func TestFeatureGatesUsageAddedLater(t *testing.T) {
+	gates := NewFeatureGates()
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.FeatureGatesVar(gates, "feature-gates", "feature gates")
+	if err := gates.Add(map[Feature]FeatureSpec{featureNewParser: {Default: false, Stage: StageAlpha}}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `      --feature-gates featureGates   feature gates
+                                     Options are:
+                                     NewParser=true|false (ALPHA - default=false)
+`
+	if usage := fs.FlagUsages(); usage != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, usage)
+	}
+	if help, err := fs.FlagHelp("feature-gates", 0); err != nil || !strings.Contains(help, "NewParser=true|false") {
+		t.Errorf("expected the detail page to list the gate; got %q, %v", help, err)
+	}
+}
@@ -58,11 +58,69 @@ type FlagSet struct {
 	// DisableBuiltinHelp toggles the built-in convention of handling -h and --help
 	DisableBuiltinHelp bool
 
+	// ArgsUsage describes the positional arguments in the Synopsis, e.g. "FILE...".
+	ArgsUsage string
+
+	// Examples are example invocations printed below the flags by the
+	// default usage message, see UsageTemplate.
+	Examples []string
+
+	// UsageTemplate replaces the default usage message with the output of a
+	// text/template, see DefaultUsageTemplate and UsageData. Like the default
+	// usage message it is not wrapped, templates can use the terminalWidth
+	// function to wrap the output themselves.
+	UsageTemplate string
+
+	// ExitFunc is called to end the program under ExitOnError, with the code
+	// returned by ExitCode. It defaults to os.Exit. Parse returns the error
+	// if ExitFunc returns.
+	ExitFunc func(code int)
+
+	// PrintErrorOnExit prints the error to the output before exiting under
+	// ExitOnError, unless it was printed already, see SetErrorPresentation.
+	PrintErrorOnExit bool
+
+	// EnableExperimental enables experimental flags regardless of the
+	// experimental gate, see SetExperimentalGate.
+	EnableExperimental bool
+
+	// AppVersion is the version of the application, used to check the
+	// RemovedIn version of deprecated flags. It defaults to the version given
+	// to SetVersion.
+	AppVersion string
+
+	// StrictDeprecation makes using a flag fail once the AppVersion reached
+	// the version the flag is removed in, see OptRemovedIn.
+	StrictDeprecation bool
+
+	// WarningHandler handles warnings such as the use of deprecated flags. It
+	// defaults to the DefaultWarningHandler, which prints them to the output.
+	WarningHandler WarningHandler
+
+	// VersionTemplate is the text/template used to print the VersionInfo by
+	// the --version flag, see DefaultVersionTemplate and SetVersion.
+	VersionTemplate string
+
+	// HelpVisibility is the highest Visibility of flags shown in help/usage
+	// messages. The built-in --help-all and --help=<level> flags raise it
+	// while printing the usage message.
+	HelpVisibility Visibility
+
 	// FlagUsageFormatter allows for custom formatting of flag usage output.
 	// Each individual item needs to be implemented. See FlagUsagesForGroupWrapped for info on what gets passed.
 	FlagUsageFormatter FlagUsageFormatter
 
+	// HelpRenderer allows for a custom layout of the flag usage output. It
+	// receives the HelpModel computed with the FlagUsageFormatter.
+	HelpRenderer HelpRenderer
+
+	// Color controls whether the default FlagUsageFormatter styles the usage
+	// output with ANSI escape sequences. See ColorMode.
+	Color ColorMode
+
 	name              string
+	version           *VersionInfo
+	versionFlag       *Flag
 	parsed            bool
 	actual            map[NormalizedName]*Flag
 	orderedActual     []*Flag
@@ -80,6 +138,23 @@ type FlagSet struct {
 
 	addedGoFlagSets []*goflag.FlagSet
 	unknownFlags    []string
+
+	groups        map[string]*FlagGroup
+	orderedGroups []*FlagGroup
+
+	errorPresentation  ErrorPresentation
+	errorPresentations map[ErrorCategory]ErrorPresentation
+
+	exitCode    int
+	exitCodeSet bool
+	exitCodes   map[ErrorCategory]int
+
+	experimentalFlag      string
+	experimentalEnv       string
+	experimentalGateSet   bool
+	experimentalRequested bool
+
+	forwarded map[*Flag]bool
 }
 
 // A Flag represents the state of a flag.
@@ -88,15 +163,32 @@ type Flag struct {
 	Shorthand           rune                // one-letter abbreviated flag
 	ShorthandOnly       bool                // If the user set only the shorthand
 	Usage               string              // help message
+	LongUsage           string              // detailed help message, shown by --help=<flag>
+	Examples            []string            // example invocations, shown by --help=<flag>
 	UsageType           string              // flag type displayed in the help message
 	DisableUnquoteUsage bool                // toggle unquoting and extraction of type from usage
 	DisablePrintDefault bool                // toggle printing of the default value in usage message
 	Value               Value               // value as set
+	Validators          []Validator         // check the value after it was set, see OptValidate
+	SliceOptions        *SliceOptions       // how the values of a slice flag are set, see OptSeparator
 	DefValue            string              // default value (as text); for usage message
+	EnvVars             []string            // environment variables bound to the flag, shown by --help=<flag>
+	DefaultFunc         DefaultFunc         // computes the default value after parsing, see OptDefaultFunc
+	DefaultDeps         []string            // flags the DefaultFunc depends on
 	Changed             bool                // If the user set the value (or if left to default)
+	Source              ValueSource         // where the value was set from, see FlagSet.SetWithSource
+	Implies             map[string]string   // values implied for other flags when this flag is set
+	ImpliedBy           string              // name of the flag that implied the value, see OptImplies
+	ShowRequired        bool                // shown as required in the synopsis, for display only; not checked by Parse
 	NoOptDefVal         string              // default value (as text); if the flag is on the command line without any options
 	Deprecated          string              // If this flag is deprecated, this string is the new or now thing to use
+	DeprecatedSince     string              // version the flag was deprecated in
+	RemovedIn           string              // version the flag will be removed in, see FlagSet.StrictDeprecation
+	ReplacedBy          string              // name of the flag that replaces this one, values are forwarded to it
+	ReplaceValue        ValueTransformFunc  // transforms values forwarded to ReplacedBy
 	Hidden              bool                // used by zulu.Command to allow flags to be hidden from help/usage text
+	Visibility          Visibility          // help level at which the flag is shown in help/usage text
+	Experimental        Stage               // stage of an experimental flag, see FlagSet.SetExperimentalGate
 	ShorthandDeprecated string              // If the shorthand of this flag is deprecated, this string is the new or now thing to use
 	Group               string              // flag group
 	Annotations         map[string][]string // Use it to annotate this specific flag for your application; used by zulu.Command bash completion code
@@ -240,7 +332,7 @@ func (f *FlagSet) HasFlags() bool {
 // that are not hidden.
 func (f *FlagSet) HasAvailableFlags() bool {
 	for _, flag := range f.formal {
-		if !flag.Hidden {
+		if !f.isHiddenFlag(flag) {
 			return true
 		}
 	}
@@ -426,13 +518,33 @@ func ShorthandLookupStr(name string) *Flag {
 
 // Set sets the value of the named flag.
 func (f *FlagSet) Set(name, value string) error {
-	normalName := f.normalizeFlagName(name)
-	flag, ok := f.formal[normalName]
+	flag, ok := f.formal[f.normalizeFlagName(name)]
 	if !ok {
 		return NewUnknownFlagError(name)
 	}
+	return f.set(flag, value, SourceCommandLine)
+}
+
+// set sets the value of flag, recording source as the flag's Source.
+func (f *FlagSet) set(flag *Flag, value string, source ValueSource) error {
+	if err := f.checkExperimental(flag); err != nil {
+		return err
+	}
+	if err := f.checkRemoved(flag); err != nil {
+		return err
+	}
+	if flag.IsDeprecated() {
+		if err := f.warnDeprecated(flag); err != nil {
+			return err
+		}
+	}
 
-	err := flag.Value.Set(value)
+	err := flag.setValidated(func() error {
+		if flag.SliceOptions != nil {
+			return flag.SliceOptions.set(flag, value)
+		}
+		return flag.Value.Set(value)
+	})
 	if err != nil {
 		var flagName string
 		if flag.Shorthand != 0 && flag.ShorthandDeprecated == "" {
@@ -446,18 +558,21 @@ func (f *FlagSet) Set(name, value string) error {
 		return fmt.Errorf("invalid argument %q for %q flag: %v", value, flagName, err)
 	}
 
-	if !flag.Changed {
+	if !flag.Changed && source != SourceImplied {
 		if f.actual == nil {
 			f.actual = make(map[NormalizedName]*Flag)
 		}
-		f.actual[normalName] = flag
+		f.actual[f.normalizeFlagName(flag.Name)] = flag
 		f.orderedActual = append(f.orderedActual, flag)
 
 		flag.Changed = true
 	}
+	flag.Source = source
+	flag.ImpliedBy = ""
+	delete(f.forwarded, flag)
 
-	if flag.Deprecated != "" {
-		fmt.Fprintf(f.Output(), "Flag --%s has been deprecated, %s\n", flag.Name, flag.Deprecated)
+	if flag.ReplacedBy != "" {
+		return f.forwardDeprecated(flag, value, source)
 	}
 	return nil
 }
@@ -507,7 +622,7 @@ func Set(name, value string) error {
 // default values of all defined command-line flags in the set. See the
 // documentation for the global function PrintDefaults for more information.
 func (f *FlagSet) PrintDefaults() {
-	usages := f.FlagUsages()
+	usages := f.GroupedFlagUsagesWrapped(0)
 	fmt.Fprint(f.Output(), usages)
 }
 
@@ -526,7 +641,7 @@ func (f *Flag) defaultIsZeroValue() bool {
 		return f.DefValue == ""
 	case *ipValue, *ipMaskValue, *ipNetValue:
 		return f.DefValue == "<nil>"
-	case *intSliceValue, *stringSliceValue, *stringArrayValue:
+	case *intSliceValue, *stringSliceValue, *stringArrayValue, *enumSliceValue, *stringSetValue:
 		return f.DefValue == "[]"
 	default:
 		switch f.DefValue {
@@ -543,6 +658,12 @@ func (f *Flag) defaultIsZeroValue() bool {
 	}
 }
 
+// usageAppender is an optional interface for values that add to the usage
+// string of their flag whenever it is shown, e.g. the known feature gates.
+type usageAppender interface {
+	appendUsage(usage string) string
+}
+
 // UnquoteUsage extracts a back-quoted name from the usage
 // string for a flag and returns it and the un-quoted usage.
 // Given "a `name` to show" it returns ("name", "a name to show").
@@ -551,6 +672,9 @@ func (f *Flag) defaultIsZeroValue() bool {
 func UnquoteUsage(flag *Flag) (name string, usage string) {
 	name = flag.UsageType
 	usage = flag.Usage
+	if u, ok := flag.Value.(usageAppender); ok {
+		usage = u.appendUsage(usage)
+	}
 
 	// Look for a back-quoted name, but avoid the strings package.
 	if !flag.DisableUnquoteUsage {
@@ -573,7 +697,9 @@ func UnquoteUsage(flag *Flag) (name string, usage string) {
 
 	if name == "" {
 		name = "value" // compatibility layer to be a drop-in replacement
-		if v, ok := flag.Value.(Typed); ok {
+		if e, ok := flag.Value.(enumValuer); ok && len(e.choiceSet().choices) > 0 {
+			name = e.choiceSet().usageType()
+		} else if v, ok := flag.Value.(Typed); ok {
 			name = v.Type()
 			switch name {
 			case "bool":
@@ -594,7 +720,7 @@ func UnquoteUsage(flag *Flag) (name string, usage string) {
 				name = "int"
 			case "intSlice", "int8Slice", "int16Slice", "int32Slice", "int64Slice":
 				name = "ints"
-			case "stringSlice", "stringArray":
+			case "stringSlice", "stringArray", "stringSet":
 				name = "strings"
 			case "uint8", "uint16", "uint32", "uint64":
 				name = "uint"
@@ -608,19 +734,20 @@ func UnquoteUsage(flag *Flag) (name string, usage string) {
 }
 
 // Splits the string `s` on whitespace into an initial substring up to
-// `i` runes in length and the remainder. Will go `slop` over `i` if
+// `i` columns in display width and the remainder. Will go `slop` over `i` if
 // that encompasses the entire string (which allows the caller to
 // avoid short orphan words on the final line).
 func wrapN(i, slop int, s string) (string, string) {
-	if i+slop > len(s) {
+	if i+slop > displayWidth(s) {
 		return s, ""
 	}
 
-	w := strings.LastIndexAny(s[:i], " \t\n")
+	n := displayIndex(s, i)
+	w := strings.LastIndexAny(s[:n], " \t\n")
 	if w <= 0 {
 		return s, ""
 	}
-	nlPos := strings.LastIndex(s[:i], "\n")
+	nlPos := strings.LastIndex(s[:n], "\n")
 	if nlPos > 0 && nlPos < w {
 		return s[:nlPos], s[nlPos+1:]
 	}
@@ -678,6 +805,9 @@ func wrap(i, w int, s string) string {
 
 func (f *FlagSet) flagUsageFormatter() FlagUsageFormatter {
 	if f.FlagUsageFormatter == nil {
+		if f.ColorEnabled() {
+			return StyledFlagUsageFormatter{}
+		}
 		return DefaultFlagUsageFormatter{}
 	}
 
@@ -686,64 +816,28 @@ func (f *FlagSet) flagUsageFormatter() FlagUsageFormatter {
 
 // FlagUsagesWrapped returns a string containing the usage information
 // for all flags in the FlagSet. Wrapped to `cols` columns (0 for no
-// wrapping)
+// wrapping, AutoWrap for the width of the terminal)
 func (f *FlagSet) FlagUsagesWrapped(cols int) string {
 	return f.FlagUsagesForGroupWrapped("", cols)
 }
 
 // FlagUsagesForGroupWrapped returns a string containing the usage information
 // for all flags in the FlagSet for group. Wrapped to `cols` columns (0 for no
-// wrapping)
+// wrapping, AutoWrap for the width of the terminal). The output is produced by
+// the FlagSet's HelpRenderer.
 func (f *FlagSet) FlagUsagesForGroupWrapped(group string, cols int) string {
 	buf := new(bytes.Buffer)
 
-	lines := make(map[string][]string)
-
-	usageFormatter := f.flagUsageFormatter()
-
-	maxlen := 0
-	f.VisitAll(func(flag *Flag) {
-		if flag.Hidden {
-			return
-		}
-
-		line := usageFormatter.Name(flag)
-
-		varname, usage := UnquoteUsage(flag)
-		if varname != "" {
-			line += " " + usageFormatter.UsageVarName(flag, varname)
-		}
-		if flag.NoOptDefVal != "" {
-			line += usageFormatter.NoOptDefValue(flag)
-		}
-
-		// This special character will be replaced with spacing once the
-		// correct alignment is calculated
-		line += "\x00"
-		if len(line) > maxlen {
-			maxlen = len(line)
-		}
-
-		line += usageFormatter.Usage(flag, usage)
-		if !flag.DisablePrintDefault && !flag.defaultIsZeroValue() {
-			line += usageFormatter.DefaultValue(flag)
-		}
-		if len(flag.Deprecated) != 0 {
-			line += usageFormatter.Deprecated(flag)
-		}
-
-		group := flag.Group
-		if _, ok := lines[group]; !ok {
-			lines[group] = make([]string, 0)
-		}
-		lines[group] = append(lines[group], line)
-	})
+	// The name column is aligned across all groups, so only the rows are
+	// restricted to the requested group. Group headers are left to the caller.
+	model := f.HelpModel()
+	groupModel := &HelpModel{NameWidth: model.NameWidth}
+	if g := model.Group(group); g != nil {
+		groupModel.Groups = append(groupModel.Groups, HelpGroup{Name: g.Name, Rows: g.Rows})
+	}
 
-	for _, line := range lines[group] {
-		sidx := strings.Index(line, "\x00")
-		spacing := strings.Repeat(" ", maxlen-sidx)
-		// maxlen + 2 comes from + 1 for the \x00 and + 1 for the (deliberate) off-by-one in maxlen-sidx
-		fmt.Fprintln(buf, line[:sidx], spacing, wrap(maxlen+2, cols, line[sidx+1:]))
+	if err := f.helpRenderer().Render(buf, groupModel, f.usageCols(cols)); err != nil {
+		fmt.Fprintln(f.Output(), err)
 	}
 
 	return buf.String()
@@ -755,14 +849,31 @@ func (f *FlagSet) FlagUsages() string {
 	return f.FlagUsagesWrapped(0)
 }
 
+// GroupedFlagUsagesWrapped returns a string containing the usage information
+// for all flags in the FlagSet, divided into titled sections per group.
+// Wrapped to `cols` columns (0 for no wrapping, AutoWrap for the width of the
+// terminal). See AddGroup to set the title, description and order of groups.
+func (f *FlagSet) GroupedFlagUsagesWrapped(cols int) string {
+	buf := new(bytes.Buffer)
+
+	if err := f.helpRenderer().Render(buf, f.HelpModel(), f.usageCols(cols)); err != nil {
+		fmt.Fprintln(f.Output(), err)
+	}
+
+	return buf.String()
+}
+
 // FlagUsagesForGroup returns a string containing the usage information for all flags in
 // the FlagSet for group
 func (f *FlagSet) FlagUsagesForGroup(group string) string {
 	return f.FlagUsagesForGroupWrapped(group, 0)
 }
 
-// Groups return an array of unique flag groups sorted in the same order
-// as flags. Empty group (unassigned) is always placed at the beginning.
+// Groups return an array of unique flag groups in the order they are printed
+// in the usage message. Groups are sorted by the order given to AddGroup.
+// Groups with the same order are sorted in the order they were added, followed
+// by the groups without metadata in lexicographical order. Empty group
+// (unassigned) is placed at the beginning unless it was added with AddGroup.
 func (f *FlagSet) Groups() []string {
 	groupsMap := make(map[string]bool)
 	groups := make([]string, 0)
@@ -782,6 +893,7 @@ func (f *FlagSet) Groups() []string {
 	if hasUngrouped {
 		groups = append([]string{""}, groups...)
 	}
+	f.sortGroups(groups)
 
 	return groups
 }
@@ -790,21 +902,21 @@ func (f *FlagSet) Groups() []string {
 // a usage message showing the default settings of all defined
 // command-line flags.
 // For an integer valued flag x, the default output has the form
-//	-x int
-//		usage-message-for-x (default 7)
-// The usage message will appear on a separate line for anything but
-// a bool flag with a one-byte name. For bool flags, the type is
-// omitted and if the flag name is one byte the usage message appears
-// on the same line. The parenthetical default is omitted if the
+//	  -x, --xflag int   usage-message-for-x (default 7)
+// with the usage messages of all flags aligned in one column. For bool
+// flags, the type is omitted. The parenthetical default is omitted if the
 // default is the zero value for the type. The listed type, here int,
 // can be changed by placing a back-quoted name in the flag's usage
 // string; the first such item in the message is taken to be a parameter
 // name to show in the message and the back quotes are stripped from
 // the message when displayed. For instance, given
-//	flag.String("I", "", "search `directory` for include files")
+//	flag.String("include", "", "search `directory` for include files", flag.OptShorthand('I'))
 // the output will be
-//	-I directory
-//		search directory for include files.
+//	  -I, --include directory   search directory for include files
+//
+// Call CommandLine.SetGoUsageLayout to get the layout of Go's flag package
+// instead, where the usage message appears on a separate line for anything
+// but a bool flag with a one-byte name.
 //
 // To change the destination for flag messages, call CommandLine.SetOutput.
 func PrintDefaults() {
@@ -813,12 +925,28 @@ func PrintDefaults() {
 
 // defaultUsage is the default function to print a usage message.
 func (f *FlagSet) defaultUsage() {
+	if f.UsageTemplate != "" {
+		if err := f.ExecuteUsageTemplate(f.Output(), f.UsageTemplate, 0); err != nil {
+			fmt.Fprintln(f.Output(), err)
+		}
+		return
+	}
 	if f.name == "" {
 		fmt.Fprintf(f.Output(), "Usage:\n")
 	} else {
-		fmt.Fprintf(f.Output(), "Usage of %s:\n", f.name)
+		fmt.Fprintf(f.Output(), "Usage: %s\n", f.synopsis(len("Usage: "), 0))
+		if f.HasAvailableFlags() {
+			fmt.Fprintln(f.Output())
+		}
 	}
 	f.PrintDefaults()
+	if len(f.Examples) > 0 {
+		fmt.Fprintln(f.Output())
+		fmt.Fprintln(f.Output(), "Examples:")
+		for _, example := range f.Examples {
+			fmt.Fprintln(f.Output(), "  "+strings.Replace(example, "\n", "\n  ", -1))
+		}
+	}
 }
 
 // NOTE: Usage is not just CommandLine.defaultUsage()
@@ -827,10 +955,13 @@ func (f *FlagSet) defaultUsage() {
 
 // Usage prints to standard error a usage message documenting all defined command-line flags.
 // The function is a variable that may be changed to point to a custom function.
-// By default it prints a simple header and calls PrintDefaults; for details about the
+// By default it prints the Synopsis and calls PrintDefaults; for details about the
 // format of the output and how to control it, see the documentation for PrintDefaults.
 var Usage = func() {
-	fmt.Fprintf(CommandLine.Output(), "Usage of %s:\n", os.Args[0])
+	fmt.Fprintf(CommandLine.Output(), "Usage: %s\n", CommandLine.Synopsis())
+	if CommandLine.HasAvailableFlags() {
+		fmt.Fprintln(CommandLine.Output())
+	}
 	PrintDefaults()
 }
 
@@ -885,6 +1016,9 @@ func (f *FlagSet) Var(value Value, name, usage string, opts ...Opt) *Flag {
 	if err != nil {
 		panic(err)
 	}
+	if flag.ReplacedBy != "" && f.Lookup(flag.ReplacedBy) == nil {
+		panic(fmt.Sprintf("replacement %q for flag %q is not defined", flag.ReplacedBy, name))
+	}
 
 	f.AddFlag(flag)
 	return flag
@@ -946,16 +1080,6 @@ func Var(value Value, name, usage string, opts ...Opt) *Flag {
 	return CommandLine.Var(value, name, usage, opts...)
 }
 
-// failf prints to standard error a formatted error and usage message and
-// returns the error.
-func (f *FlagSet) failf(format string, a ...interface{}) error {
-	f.usage()
-	err := fmt.Errorf(format, a...)
-	fmt.Fprintln(f.Output())
-	fmt.Fprintln(f.Output(), err)
-	return err
-}
-
 // usage calls the Usage method for the flag set, or the usage function if
 // the flag set is CommandLine.
 func (f *FlagSet) usage() {
@@ -995,7 +1119,7 @@ func (f *FlagSet) parseLongArg(s string, args []string, fn parseFunc) (outArgs [
 	outArgs = args
 	name := s[2:]
 	if len(name) == 0 || name[0] == '-' || name[0] == '=' {
-		err = f.failf("bad flag syntax: %s", s)
+		err = f.failf(ErrorSyntax, nil, "bad flag syntax: %s", s)
 		return
 	}
 
@@ -1006,8 +1130,29 @@ func (f *FlagSet) parseLongArg(s string, args []string, fn parseFunc) (outArgs [
 	if !exists || (flag != nil && flag.ShorthandOnly) {
 		switch {
 		case !exists && name == "help" && !f.DisableBuiltinHelp:
-			f.usage()
-			err = ErrHelp
+			// '--help', '--help=level', '--help=format' or '--help=flag'
+			var format string
+			if len(split) == 2 {
+				format = split[1]
+			} else if len(outArgs) > 0 && len(outArgs[0]) > 1 && outArgs[0][0] == '-' {
+				// '--help --flag'
+				if topic := f.lookupHelpTopic(outArgs[0]); topic != nil {
+					err = f.printFlagHelp(topic)
+					return
+				}
+			}
+			err = f.printHelp(format, f.HelpVisibility)
+			return
+		case !exists && name == "help-all" && !f.DisableBuiltinHelp:
+			// '--help-all' or '--help-all=format'
+			var format string
+			if len(split) == 2 {
+				format = split[1]
+			}
+			err = f.printHelp(format, VisibilityDebug)
+			return
+		case !exists && f.isExperimentalGateFlag(name):
+			// '--enable-experimental', handled by scanExperimentalGate
 			return
 		case f.ParseErrorsAllowlist.UnknownFlags || (flag != nil && flag.ShorthandOnly):
 			// --unknown=unknownval arg ...
@@ -1019,7 +1164,7 @@ func (f *FlagSet) parseLongArg(s string, args []string, fn parseFunc) (outArgs [
 			outArgs = f.stripUnknownFlagValue(outArgs)
 			return
 		default:
-			err = f.failf(NewUnknownFlagError(name).Error())
+			err = f.fail(&ParseError{Category: ErrorUnknownFlag, Err: NewUnknownFlagError(name)})
 			return
 		}
 	}
@@ -1037,13 +1182,22 @@ func (f *FlagSet) parseLongArg(s string, args []string, fn parseFunc) (outArgs [
 		outArgs = outArgs[1:]
 	} else {
 		// '--flag' (arg was required)
-		err = f.failf("flag needs an argument: %s", s)
+		err = f.failf(ErrorMissingArgument, flag, "flag needs an argument: %s", s)
+		return
+	}
+
+	if err = f.checkExperimental(flag); err != nil {
+		err = f.fail(&ParseError{Category: ErrorExperimentalFlag, Flag: flag, Err: err})
+		return
+	}
+	if ok, verr := f.handleVersion(flag, value); ok {
+		err = verr
 		return
 	}
 
 	err = fn(flag, value)
 	if err != nil {
-		err = f.failf(err.Error())
+		err = f.fail(&ParseError{Category: valueErrorCategory(err), Flag: flag, Err: err})
 	}
 	return
 }
@@ -1057,8 +1211,7 @@ func (f *FlagSet) parseSingleShortArg(shorthands string, args []string, fn parse
 	if !exists {
 		switch {
 		case char == 'h' && !f.DisableBuiltinHelp:
-			f.usage()
-			err = ErrHelp
+			err = f.printHelp("", f.HelpVisibility)
 			return
 		case f.ParseErrorsAllowlist.UnknownFlags:
 			if len(shorthands) > 2 {
@@ -1074,7 +1227,7 @@ func (f *FlagSet) parseSingleShortArg(shorthands string, args []string, fn parse
 			}
 			return
 		default:
-			err = f.failf("unknown shorthand flag: %q in -%s", char, shorthands)
+			err = f.failf(ErrorUnknownFlag, nil, "unknown shorthand flag: %q in -%s", char, shorthands)
 			return
 		}
 	}
@@ -1097,17 +1250,34 @@ func (f *FlagSet) parseSingleShortArg(shorthands string, args []string, fn parse
 		outArgs = args[1:]
 	} else {
 		// '-f' (arg was required)
-		err = f.failf("flag needs an argument: %q in -%s", char, shorthands)
+		err = f.failf(ErrorMissingArgument, flag, "flag needs an argument: %q in -%s", char, shorthands)
 		return
 	}
 
 	if flag.ShorthandDeprecated != "" {
-		fmt.Fprintf(f.Output(), "Flag shorthand -%c has been deprecated, %s\n", flag.Shorthand, flag.ShorthandDeprecated)
+		err = f.warn(&Warning{
+			Kind:    WarningDeprecatedShorthand,
+			Flag:    flag,
+			Message: fmt.Sprintf("Flag shorthand -%c has been deprecated, %s", flag.Shorthand, flag.ShorthandDeprecated),
+		})
+		if err != nil {
+			err = f.fail(&ParseError{Category: ErrorWarning, Flag: flag, Err: err})
+			return
+		}
+	}
+
+	if err = f.checkExperimental(flag); err != nil {
+		err = f.fail(&ParseError{Category: ErrorExperimentalFlag, Flag: flag, Err: err})
+		return
+	}
+	if ok, verr := f.handleVersion(flag, value); ok {
+		err = verr
+		return
 	}
 
 	err = fn(flag, value)
 	if err != nil {
-		err = f.failf(err.Error())
+		err = f.fail(&ParseError{Category: valueErrorCategory(err), Flag: flag, Err: err})
 	}
 	return
 }
@@ -1168,22 +1338,30 @@ func (f *FlagSet) parseAll(arguments []string, fn parseFunc) error {
 	}
 	f.parsed = true
 
-	if len(arguments) == 0 {
-		return nil
+	err := f.scanExperimentalGate(arguments)
+	if err == nil && len(arguments) > 0 {
+		f.args = make([]string, 0, len(arguments))
+		err = f.parseArgs(arguments, fn)
+	}
+	if err == nil {
+		err = f.applyPresets()
+	}
+	if err == nil {
+		err = f.applyImplies()
+	}
+	if err == nil {
+		err = f.applyDefaultFuncs()
+	}
+	if err == nil {
+		err = f.checkSliceItems()
 	}
-
-	f.args = make([]string, 0, len(arguments))
-
-	err := f.parseArgs(arguments, fn)
 	if err != nil {
 		switch f.errorHandling {
 		case ContinueOnError:
 			return err
 		case ExitOnError:
-			if err == ErrHelp {
-				os.Exit(0)
-			}
-			os.Exit(2)
+			f.exit(err)
+			return err
 		case PanicOnError:
 			panic(err)
 		}
@@ -1194,7 +1372,8 @@ func (f *FlagSet) parseAll(arguments []string, fn parseFunc) error {
 // Parse parses flag definitions from the argument list, which should not
 // include the command name.  Must be called after all flags in the FlagSet
 // are defined and before flags are accessed by the program.
-// The return value will be ErrHelp if -help was set but not defined.
+// The return value will be ErrHelp if -help was set but not defined, and
+// ErrVersion if --version was set, see SetVersion.
 func (f *FlagSet) Parse(arguments []string) error {
 	set := func(flag *Flag, value string) error {
 		return f.Set(flag.Name, value)
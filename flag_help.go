@@ -0,0 +1,145 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// lookupHelpTopic returns the flag named by the value of --help=<flag>. The
+// name can be given with or without dashes, e.g. "filter", "--filter" or "-f".
+// Flags hidden from help/usage text are not returned.
+func (f *FlagSet) lookupHelpTopic(name string) *Flag {
+	var flag *Flag
+	switch {
+	case strings.HasPrefix(name, "--"):
+		flag = f.Lookup(name[2:])
+	case strings.HasPrefix(name, "-") && utf8.RuneCountInString(name) == 2:
+		r, _ := utf8.DecodeRuneInString(name[1:])
+		flag = f.ShorthandLookup(r)
+	default:
+		flag = f.Lookup(name)
+	}
+
+	if flag == nil || f.isHiddenFlag(flag) {
+		return nil
+	}
+	return flag
+}
+
+// FlagHelp returns the detailed help of the named flag, as printed by
+// --help=<flag>: its usage, long usage, type, default values, shorthand,
+// environment variables and examples. Wrapped to `cols` columns (0 for no
+// wrapping, AutoWrap for the width of the terminal). The name is normalized
+// like in Lookup.
+func (f *FlagSet) FlagHelp(name string, cols int) (string, error) {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return "", NewUnknownFlagError(name)
+	}
+	cols = f.usageCols(cols)
+
+	buf := new(bytes.Buffer)
+	usageFormatter := f.flagUsageFormatter()
+
+	varname, usage := UnquoteUsage(flag)
+	line := usageFormatter.Name(flag)
+	if varname != "" {
+		line += " " + usageFormatter.UsageVarName(flag, varname)
+	}
+	if flag.NoOptDefVal != "" {
+		line += usageFormatter.NoOptDefValue(flag)
+	}
+	fmt.Fprintln(buf, line)
+
+	if usage != "" {
+		fmt.Fprintln(buf, "    "+wrap(4, cols, usageFormatter.Usage(flag, usage)))
+	}
+	if flag.LongUsage != "" {
+		fmt.Fprintln(buf)
+		fmt.Fprintln(buf, "    "+wrap(4, cols, flag.LongUsage))
+	}
+
+	var details [][2]string
+	if v, ok := flag.Value.(Typed); ok {
+		details = append(details, [2]string{"Type", v.Type()})
+	}
+	if constraint := flag.Constraint(); constraint != "" {
+		details = append(details, [2]string{"Valid values", constraint})
+	}
+	if flag.DefValue != "" {
+		details = append(details, [2]string{"Default", flag.DefValue})
+	}
+	if flag.NoOptDefVal != "" {
+		details = append(details, [2]string{"Without value", flag.NoOptDefVal})
+	}
+	if flag.Shorthand != 0 && flag.ShorthandDeprecated == "" && !flag.ShorthandOnly {
+		details = append(details, [2]string{"Alias", fmt.Sprintf("-%c", flag.Shorthand)})
+	}
+	if len(flag.EnvVars) > 0 {
+		details = append(details, [2]string{"Environment", envNote(flag)})
+	}
+	if flag.Group != "" {
+		details = append(details, [2]string{"Group", f.groupTitle(flag.Group)})
+	}
+	if len(flag.Implies) > 0 {
+		var implies []string
+		for _, name := range sortedImplies(flag) {
+			implies = append(implies, fmt.Sprintf("--%s=%s", name, flag.Implies[name]))
+		}
+		details = append(details, [2]string{"Implies", strings.Join(implies, " ")})
+	}
+	if impliedBy := f.ImpliedBy(flag.Name); len(impliedBy) > 0 {
+		details = append(details, [2]string{"Implied by", "--" + strings.Join(impliedBy, ", --")})
+	}
+	if flag.Experimental != "" {
+		details = append(details, [2]string{"Stage", string(flag.Experimental)})
+	}
+	if flag.IsDeprecated() {
+		details = append(details, [2]string{"Deprecated", flag.DeprecationNote()})
+	}
+
+	if len(details) > 0 {
+		fmt.Fprintln(buf)
+		width := 0
+		for _, d := range details {
+			if len(d[0]) > width {
+				width = len(d[0])
+			}
+		}
+		for _, d := range details {
+			label := d[0] + ":"
+			fmt.Fprintf(buf, "    %-*s %s\n", width+1, label, wrap(width+6, cols, d[1]))
+		}
+	}
+
+	if len(flag.Examples) > 0 {
+		fmt.Fprintln(buf)
+		fmt.Fprintln(buf, "  Examples:")
+		for _, example := range flag.Examples {
+			fmt.Fprintln(buf, "    "+strings.Replace(example, "\n", "\n    ", -1))
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// printFlagHelp prints the detailed help of flag to the FlagSet's output.
+func (f *FlagSet) printFlagHelp(flag *Flag) error {
+	help, err := f.FlagHelp(flag.Name, 0)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(f.Output(), help)
+	return ErrHelp
+}
+
+// envNote returns the environment variables of the flag as shown by
+// --help=<flag>, e.g. "$APP_PORT, $PORT".
+func envNote(flag *Flag) string {
+	return "$" + strings.Join(flag.EnvVars, ", $")
+}
@@ -0,0 +1,156 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"testing"
+)
+
+func setUpFlagHelpFlagSet(buf *bytes.Buffer) *FlagSet {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetOutput(buf)
+	fs.SetNormalizeFunc(wordSepNormalizeFunc)
+	fs.String("filter", "", "only show items matching `expr`",
+		OptShorthand('f'),
+		OptGroup("query"),
+		OptLongUsage("The expression is a comma separated list of key=value pairs. All pairs must match for an item to be shown."),
+		OptExamples("test --filter kind=pod", "test -f kind=pod,ns=default"),
+	)
+	fs.Int("log-level", 2, "verbosity of the logs", OptNoOptDefVal("4"))
+	fs.Bool("secret", false, "secret flag", OptHidden())
+	return fs
+}
+
+const filterHelp = `  -f, --filter expr
+    only show items matching expr
+
+    The expression is a comma separated list of key=value pairs.
+    All pairs must match for an item to be shown.
+
+    Type:  string
+    Alias: -f
+    Group: query
+
+  Examples:
+    test --filter kind=pod
+    test -f kind=pod,ns=default
+`
+
+const logLevelHelp = `      --log.level int[=4]
+    verbosity of the logs
+
+    Type:          int
+    Default:       2
+    Without value: 4
+`
+
+func TestFlagHelp(t *testing.T) {
+	var buf bytes.Buffer
+	fs := setUpFlagHelpFlagSet(&buf)
+
+	got, err := fs.FlagHelp("filter", 72)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != filterHelp {
+		t.Errorf("expected\n%s\ngot\n%s", filterHelp, got)
+	}
+
+	got, err = fs.FlagHelp("log_level", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != logLevelHelp {
+		t.Errorf("expected\n%s\ngot\n%s", logLevelHelp, got)
+	}
+
+	if _, err := fs.FlagHelp("unknown", 0); err == nil {
+		t.Error("expected an error for an unknown flag")
+	}
+}
+
+func TestHelpFlagTopic(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"--help=log-level"}, logLevelHelp},
+		{[]string{"--help=--log_level"}, logLevelHelp},
+		{[]string{"--help", "--log.level"}, logLevelHelp},
+		{[]string{"--help=-f"}, ""},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		fs := setUpFlagHelpFlagSet(&buf)
+		usageCalled := false
+		fs.Usage = func() { usageCalled = true }
+
+		if err := fs.Parse(tt.args); err != ErrHelp {
+			t.Fatalf("%v: expected ErrHelp; got %v", tt.args, err)
+		}
+		if usageCalled {
+			t.Errorf("%v: expected the flag help instead of the usage", tt.args)
+		}
+		if tt.expected != "" && buf.String() != tt.expected {
+			t.Errorf("%v: expected\n%s\ngot\n%s", tt.args, tt.expected, buf.String())
+		}
+	}
+}
+
+func TestHelpFlagTopicHidden(t *testing.T) {
+	var buf bytes.Buffer
+	fs := setUpFlagHelpFlagSet(&buf)
+
+	if err := fs.Parse([]string{"--help=secret"}); err == nil || err == ErrHelp {
+		t.Errorf("expected an error for a hidden flag; got %v", err)
+	}
+
+	// '--help --secret' falls back to the regular usage
+	usageCalled := false
+	fs.Usage = func() { usageCalled = true }
+	if err := fs.Parse([]string{"--help", "--secret"}); err != ErrHelp || !usageCalled {
+		t.Errorf("expected the usage to be printed; got %v", err)
+	}
+}
+
+func TestHelpFlagTopicShadowsFormat(t *testing.T) {
+	var buf bytes.Buffer
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetOutput(&buf)
+	fs.Bool("json", false, "print json")
+	fs.Usage = func() { t.Error("expected the flag help instead of the usage") }
+
+	if err := fs.Parse([]string{"--help=json"}); err != ErrHelp {
+		t.Fatalf("expected ErrHelp; got %v", err)
+	}
+	if expected := "      --json\n    print json\n\n    Type:          bool\n    Default:       false\n    Without value: true\n"; buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
+
+func TestFlagHelpEnv(t *testing.T) {
+	var buf bytes.Buffer
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetOutput(&buf)
+	fs.Int("port", 80, "port to listen on", OptEnv("TEST_PORT", "PORT"))
+
+	got, err := fs.FlagHelp("port", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "      --port int\n    port to listen on\n\n    Type:        int\n    Default:     80\n    Environment: $TEST_PORT, $PORT\n"
+	if got != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, got)
+	}
+
+	setEnv(t, "TEST_PORT", "8080")
+	if err := fs.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if fs.MustGetInt("port") != 80 {
+		t.Errorf("expected the environment to not be read; got %d", fs.MustGetInt("port"))
+	}
+}
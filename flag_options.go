@@ -50,6 +50,34 @@ func (o optShorthandOnlyImpl) apply(c *Flag) error { c.ShorthandOnly = true; ret
 // OptShorthandOnly If the user set only the shorthand
 func OptShorthandOnly() Opt { return optShorthandOnlyImpl{} }
 
+type optLongUsageImpl struct{ longUsage string }
+
+func (o optLongUsageImpl) apply(c *Flag) error { c.LongUsage = o.longUsage; return nil }
+
+// OptLongUsage detailed help message, shown by --help=<flag>
+func OptLongUsage(longUsage string) Opt { return optLongUsageImpl{longUsage: longUsage} }
+
+type optExamplesImpl struct{ examples []string }
+
+func (o optExamplesImpl) apply(c *Flag) error {
+	c.Examples = append(c.Examples, o.examples...)
+	return nil
+}
+
+// OptExamples example invocations, shown by --help=<flag>
+func OptExamples(examples ...string) Opt { return optExamplesImpl{examples: examples} }
+
+type optEnvImpl struct{ names []string }
+
+func (o optEnvImpl) apply(c *Flag) error {
+	c.EnvVars = append(c.EnvVars, o.names...)
+	return nil
+}
+
+// OptEnv environment variables the application binds to the flag, shown by
+// --help=<flag>; the flag is not read from them
+func OptEnv(names ...string) Opt { return optEnvImpl{names: names} }
+
 type optUsageTypeImpl struct{ usageType string }
 
 func (o optUsageTypeImpl) apply(c *Flag) error { c.UsageType = o.usageType; return nil }
@@ -78,6 +106,29 @@ func (o optDefValueImpl) apply(c *Flag) error { c.DefValue = o.defValue; return
 // OptDefValue default value (as text); for usage message
 func OptDefValue(defValue string) Opt { return optDefValueImpl{defValue: defValue} }
 
+type optDefaultFuncImpl struct {
+	fn        DefaultFunc
+	dependsOn []string
+}
+
+func (o optDefaultFuncImpl) apply(c *Flag) error {
+	if o.fn == nil {
+		return fmt.Errorf("default func for flag %q must be set", c.Name)
+	}
+
+	c.DefaultFunc = o.fn
+	c.DefaultDeps = o.dependsOn
+	return nil
+}
+
+// OptDefaultFunc computes the default value after parsing if the flag was not
+// set, after computing the default values of the flags in dependsOn. It is
+// not called for usage messages, use OptDefValue to show a placeholder such as
+// "<data-dir>/cache".
+func OptDefaultFunc(fn DefaultFunc, dependsOn ...string) Opt {
+	return optDefaultFuncImpl{fn: fn, dependsOn: dependsOn}
+}
+
 type optNoOptDefValImpl struct{ noOptDefVal string }
 
 func (o optNoOptDefValImpl) apply(c *Flag) error { c.NoOptDefVal = o.noOptDefVal; return nil }
@@ -101,6 +152,69 @@ func (o optDeprecatedImpl) apply(c *Flag) error {
 // this flag will also print the given usageMessage.
 func OptDeprecated(msg string) Opt { return optDeprecatedImpl{msg: msg} }
 
+type optDeprecatedSinceImpl struct{ version string }
+
+func (o optDeprecatedSinceImpl) apply(c *Flag) error { c.DeprecatedSince = o.version; return nil }
+
+// OptDeprecatedSince version the flag was deprecated in. Unlike OptDeprecated
+// the flag stays in help/usage text, with a deprecation note.
+func OptDeprecatedSince(version string) Opt { return optDeprecatedSinceImpl{version: version} }
+
+type optRemovedInImpl struct{ version string }
+
+func (o optRemovedInImpl) apply(c *Flag) error { c.RemovedIn = o.version; return nil }
+
+// OptRemovedIn version the deprecated flag will be removed in. Using the flag
+// fails from that version on if the FlagSet has StrictDeprecation enabled.
+func OptRemovedIn(version string) Opt { return optRemovedInImpl{version: version} }
+
+type optReplacedByImpl struct {
+	name      string
+	transform ValueTransformFunc
+}
+
+func (o optReplacedByImpl) apply(c *Flag) error {
+	if o.name == "" {
+		return fmt.Errorf("replacement for flag %q must be set", c.Name)
+	}
+
+	c.ReplacedBy = o.name
+	c.ReplaceValue = o.transform
+	return nil
+}
+
+// OptReplacedBy deprecates the flag in favor of the named flag, which must be
+// defined before the flag. Values set on the flag are forwarded to the
+// replacement, transformed by transform unless it is nil, unless the
+// replacement was set itself.
+func OptReplacedBy(name string, transform ValueTransformFunc) Opt {
+	return optReplacedByImpl{name: name, transform: transform}
+}
+
+type optImpliesImpl struct{ implies map[string]string }
+
+func (o optImpliesImpl) apply(c *Flag) error {
+	if c.Implies == nil {
+		c.Implies = make(map[string]string, len(o.implies))
+	}
+	for name, value := range o.implies {
+		c.Implies[name] = value
+	}
+	return nil
+}
+
+// OptImplies values set on other flags when this flag is set, unless they were
+// set otherwise. Boolean flags only imply values when they are true.
+func OptImplies(implies map[string]string) Opt { return optImpliesImpl{implies: implies} }
+
+type optShowRequiredImpl struct{}
+
+func (o optShowRequiredImpl) apply(c *Flag) error { c.ShowRequired = true; return nil }
+
+// OptShowRequired shows the flag as required in the synopsis. It is for
+// display only: Parse does not check that the flag was set.
+func OptShowRequired() Opt { return optShowRequiredImpl{} }
+
 type optHiddenImpl struct{}
 
 func (o optHiddenImpl) apply(c *Flag) error { c.Hidden = true; return nil }
@@ -108,6 +222,209 @@ func (o optHiddenImpl) apply(c *Flag) error { c.Hidden = true; return nil }
 // OptHidden used by zulu.Command to allow flags to be hidden from help/usage text
 func OptHidden() Opt { return optHiddenImpl{} }
 
+type optVisibilityImpl struct{ visibility Visibility }
+
+func (o optVisibilityImpl) apply(c *Flag) error { c.Visibility = o.visibility; return nil }
+
+// OptVisibility help level at which the flag is shown in help/usage text
+func OptVisibility(visibility Visibility) Opt { return optVisibilityImpl{visibility: visibility} }
+
+type optExperimentalImpl struct{ stage Stage }
+
+func (o optExperimentalImpl) apply(c *Flag) error {
+	if o.stage == "" {
+		return fmt.Errorf("experimental stage for flag %q must be set", c.Name)
+	}
+
+	c.Experimental = o.stage
+	return nil
+}
+
+// OptExperimental marks the flag as experimental at the given stage. It is
+// hidden from help/usage text and rejected unless experimental flags are
+// enabled, see FlagSet.SetExperimentalGate.
+func OptExperimental(stage Stage) Opt { return optExperimentalImpl{stage: stage} }
+
+type optValidateImpl struct{ validators []Validator }
+
+func (o optValidateImpl) apply(c *Flag) error {
+	for _, v := range o.validators {
+		if v == nil {
+			return fmt.Errorf("validator for flag %q must be set", c.Name)
+		}
+	}
+
+	c.Validators = append(c.Validators, o.validators...)
+	return nil
+}
+
+// OptValidate checks the value of the flag every time it is set, e.g.
+// OptValidate(ValidateRange(1, 65535)). The constraints of the validators are
+// shown in help/usage text.
+func OptValidate(validators ...Validator) Opt { return optValidateImpl{validators: validators} }
+
+type optCaseInsensitiveImpl struct{}
+
+func (o optCaseInsensitiveImpl) apply(c *Flag) error {
+	e, ok := c.Value.(enumValuer)
+	if !ok {
+		return fmt.Errorf("flag %q is not an enum or set flag", c.Name)
+	}
+
+	e.choiceSet().caseInsensitive = true
+	if s, ok := c.Value.(*stringSetValue); ok {
+		if err := s.canonicalize(); err != nil {
+			return fmt.Errorf("invalid default value for flag %q: %v", c.Name, err)
+		}
+		c.DefValue = s.String()
+	}
+	return nil
+}
+
+// OptCaseInsensitive matches the choices of an enum flag, or the members and
+// duplicates of a set flag, regardless of case
+func OptCaseInsensitive() Opt { return optCaseInsensitiveImpl{} }
+
+type optSortedImpl struct{}
+
+func (o optSortedImpl) apply(c *Flag) error {
+	s, ok := c.Value.(*stringSetValue)
+	if !ok {
+		return fmt.Errorf("flag %q is not a set flag", c.Name)
+	}
+
+	s.sorted = true
+	*s.value = s.add(*s.value)
+	c.DefValue = s.String()
+	return nil
+}
+
+// OptSorted keeps the members of a set flag sorted instead of in the order
+// they were added
+func OptSorted() Opt { return optSortedImpl{} }
+
+type optMembersImpl struct{ members []string }
+
+func (o optMembersImpl) apply(c *Flag) error {
+	s, ok := c.Value.(*stringSetValue)
+	if !ok {
+		return fmt.Errorf("flag %q is not a set flag", c.Name)
+	}
+	if len(o.members) == 0 {
+		return fmt.Errorf("members of flag %q must be set", c.Name)
+	}
+
+	if err := s.restrict(o.members); err != nil {
+		return fmt.Errorf("invalid default value for flag %q: %v", c.Name, err)
+	}
+	c.DefValue = s.String()
+	return c.SetAnnotation(EnumChoicesAnnotation, o.members)
+}
+
+// OptMembers restricts a set flag to the given members, which are shown in
+// help/usage text like the choices of an enum flag
+func OptMembers(members ...string) Opt { return optMembersImpl{members: members} }
+
+type optSeparatorImpl struct{ sep string }
+
+func (o optSeparatorImpl) apply(c *Flag) error {
+	opts, err := sliceOptionsOf(c)
+	if err != nil {
+		return err
+	}
+	if o.sep == "" {
+		return fmt.Errorf("separator for flag %q must be set", c.Name)
+	}
+
+	opts.Separator = o.sep
+	return nil
+}
+
+// OptSeparator splits the values of a slice flag on sep instead of commas
+func OptSeparator(sep string) Opt { return optSeparatorImpl{sep: sep} }
+
+type optNoSplitImpl struct{}
+
+func (o optNoSplitImpl) apply(c *Flag) error {
+	opts, err := sliceOptionsOf(c)
+	if err != nil {
+		return err
+	}
+
+	opts.NoSplit = true
+	return nil
+}
+
+// OptNoSplit takes every value of a slice flag as one item, like StringArray flags
+func OptNoSplit() Opt { return optNoSplitImpl{} }
+
+type optAppendDefaultImpl struct{}
+
+func (o optAppendDefaultImpl) apply(c *Flag) error {
+	opts, err := sliceOptionsOf(c)
+	if err != nil {
+		return err
+	}
+
+	opts.AppendDefault = true
+	return nil
+}
+
+// OptAppendDefault appends the values of a slice flag to its default instead of replacing it
+func OptAppendDefault() Opt { return optAppendDefaultImpl{} }
+
+type optItemsImpl struct{ min, max int }
+
+func (o optItemsImpl) apply(c *Flag) error {
+	opts, err := sliceOptionsOf(c)
+	if err != nil {
+		return err
+	}
+	if o.min < 0 || o.max < 0 || (o.max > 0 && o.min > o.max) {
+		return fmt.Errorf("invalid item limits %d-%d for flag %q", o.min, o.max, c.Name)
+	}
+
+	opts.MinItems = o.min
+	opts.MaxItems = o.max
+	return nil
+}
+
+// OptItems limits the number of items of a slice flag, if it is set. A max of 0 means no maximum.
+func OptItems(min, max int) Opt { return optItemsImpl{min: min, max: max} }
+
+type optClearTokenImpl struct{ token string }
+
+func (o optClearTokenImpl) apply(c *Flag) error {
+	opts, err := sliceOptionsOf(c)
+	if err != nil {
+		return err
+	}
+	if o.token == "" {
+		return fmt.Errorf("clear token for flag %q must be set", c.Name)
+	}
+
+	opts.ClearToken = o.token
+	return nil
+}
+
+// OptClearToken a value that removes all items of a slice flag, e.g. "none"
+func OptClearToken(token string) Opt { return optClearTokenImpl{token: token} }
+
+type optNumberSyntaxImpl struct{ syntax NumberSyntax }
+
+func (o optNumberSyntaxImpl) apply(c *Flag) error {
+	n, ok := c.Value.(numberValue)
+	if !ok {
+		return fmt.Errorf("flag %q is not a numeric flag", c.Name)
+	}
+
+	n.setNumberSyntax(o.syntax)
+	return nil
+}
+
+// OptNumberSyntax the syntax a numeric flag accepts, e.g. NumberStrict for plain decimal numbers
+func OptNumberSyntax(syntax NumberSyntax) Opt { return optNumberSyntaxImpl{syntax: syntax} }
+
 type optShorthandDeprecatedImpl struct{ msg string }
 
 func (o optShorthandDeprecatedImpl) apply(c *Flag) error {
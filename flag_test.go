@@ -1052,7 +1052,7 @@ func TestOutputExitOnError(t *testing.T) {
 	cmd.Stderr = mockStderr
 	err := cmd.Run()
 	if e, ok := err.(*exec.ExitError); ok && !e.Success() {
-		want := "Usage of " + t.Name() + ":\n\nunknown flag: --unknown\n"
+		want := "Usage: " + t.Name() + " [-h]\n\nunknown flag: --unknown\n"
 		if got := mockStderr.String(); got != want {
 			t.Errorf("got '%s', want '%s'", got, want)
 		}
@@ -1217,6 +1217,21 @@ func TestHelp(t *testing.T) {
 	}
 }
 
+func TestShowRequiredFlags(t *testing.T) {
+	fs := NewFlagSet("required", ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.String("config", "", "config path", OptShowRequired())
+	fs.Bool("verbose", false, "verbose output")
+
+	// required flags are shown in the synopsis, checking them is left to the application
+	if err := fs.Parse([]string{"--verbose"}); err != nil {
+		t.Errorf("expected no error; got %v", err)
+	}
+	if !fs.Lookup("config").ShowRequired {
+		t.Error("expected config to be shown as required")
+	}
+}
+
 func TestNoInterspersed(t *testing.T) {
 	f := NewFlagSet("test", ContinueOnError)
 	f.SetInterspersed(false)
@@ -1562,10 +1577,12 @@ func TestUnquoteUsage(t *testing.T) {
 	var buf bytes.Buffer
 	var fs = NewFlagSet(t.Name(), ContinueOnError)
 	fs.SetOutput(&buf)
-	want := "Usage of TestUnquoteUsage:\n"
+	synopsis := "Usage: TestUnquoteUsage [-h]"
+	want := ""
 
 	var check = func(want string) {
 		fs.defaultUsage()
+		want = synopsis + "\n\n" + want
 		if want != buf.String() {
 			t.Fatalf("\nexpected:\n%s\n\ngot:\n%s", want, buf.String())
 		}
@@ -1574,22 +1591,25 @@ func TestUnquoteUsage(t *testing.T) {
 
 	// normal usage
 	fs.String("flagA", "", "flagA: `ctype1`")
+	synopsis += " [--flagA ctype1]"
 	want += "      --flagA ctype1   flagA: ctype1\n"
 	check(want)
 
 	// custom type
 	fs.String("flagB", "", "flagB: `ctype2`")
 	fs.Lookup("flagB").UsageType = "foo"
+	synopsis += " [--flagB foo]"
 	want += "      --flagB foo      flagB: ctype2\n"
 	check(want)
 
 	// disable unquoting
 	fs.String("flagC", "", "flagC: `ctype3`")
 	fs.Lookup("flagC").DisableUnquoteUsage = true
+	synopsis += " [--flagC string]"
 	want += "      --flagC string   flagC: `ctype3`\n"
 	fs.defaultUsage()
-	if want != buf.String() {
-		t.Fatalf("\nexpected:\n%s\n\ngot:\n%s", want, buf.String())
+	if synopsis+"\n\n"+want != buf.String() {
+		t.Fatalf("\nexpected:\n%s\n\ngot:\n%s", synopsis+"\n\n"+want, buf.String())
 	}
 	buf.Reset()
 
@@ -1597,10 +1617,11 @@ func TestUnquoteUsage(t *testing.T) {
 	fs.String("flagD", "", "flagD: `ctype4`")
 	fs.Lookup("flagD").UsageType = "bar"
 	fs.Lookup("flagD").DisableUnquoteUsage = true
+	synopsis += " [--flagD bar]"
 	want += "      --flagD bar      flagD: `ctype4`\n"
 	fs.defaultUsage()
-	if want != buf.String() {
-		t.Fatalf("\nexpected:\n%s\n\ngot:\n%s", want, buf.String())
+	if synopsis+"\n\n"+want != buf.String() {
+		t.Fatalf("\nexpected:\n%s\n\ngot:\n%s", synopsis+"\n\n"+want, buf.String())
 	}
 	buf.Reset()
 }
@@ -6,28 +6,31 @@ package zflag
 import "strconv"
 
 // -- float32 Value
-type float32Value float32
+type float32Value struct {
+	numberParser
+	value *float32
+}
 
 func newFloat32Value(val float32, p *float32) *float32Value {
 	*p = val
-	return (*float32Value)(p)
+	return &float32Value{value: p}
 }
 
 func (f *float32Value) Set(s string) error {
-	v, err := strconv.ParseFloat(s, 32)
-	*f = float32Value(v)
+	v, err := f.parseFloat(s, 32)
+	*f.value = float32(v)
 	return err
 }
 
 func (s *float32Value) Get() interface{} {
-	return float32(*s)
+	return *s.value
 }
 
 func (f *float32Value) Type() string {
 	return "float32"
 }
 
-func (f *float32Value) String() string { return strconv.FormatFloat(float64(*f), 'g', -1, 32) }
+func (f *float32Value) String() string { return strconv.FormatFloat(float64(*f.value), 'g', -1, 32) }
 
 // GetFloat32 return the float32 value of a flag with the given name
 func (f *FlagSet) GetFloat32(name string) (float32, error) {
@@ -5,12 +5,13 @@ package zflag
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 )
 
 // -- float32Slice Value
 type float32SliceValue struct {
+	numberParser
+	sliceLimits
 	value   *[]float32
 	changed bool
 }
@@ -27,13 +28,10 @@ func (s *float32SliceValue) Set(val string) error {
 	out := make([]float32, len(ss))
 	for i, d := range ss {
 		var err error
-		var temp64 float64
-		temp64, err = strconv.ParseFloat(d, 32)
+		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
-		out[i] = float32(temp64)
-
 	}
 	if !s.changed {
 		*s.value = out
@@ -61,7 +59,7 @@ func (s *float32SliceValue) String() string {
 }
 
 func (s *float32SliceValue) fromString(val string) (float32, error) {
-	t64, err := strconv.ParseFloat(val, 32)
+	t64, err := s.parseFloat(val, 32)
 	if err != nil {
 		return 0, err
 	}
@@ -73,6 +71,14 @@ func (s *float32SliceValue) toString(val float32) string {
 }
 
 func (s *float32SliceValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *float32SliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *float32SliceValue) appendValue(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
 		return err
@@ -81,13 +87,13 @@ func (s *float32SliceValue) Append(val string) error {
 	return nil
 }
 
-func (s *float32SliceValue) Replace(val []string) error {
+func (s *float32SliceValue) replaceValues(val []string) error {
 	out := make([]float32, len(val))
 	for i, d := range val {
 		var err error
 		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
 	}
 	*s.value = out
@@ -102,6 +108,11 @@ func (s *float32SliceValue) GetSlice() []string {
 	return out
 }
 
+func (s *float32SliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetFloat32Slice return the []float32 value of a flag with the given name
 func (f *FlagSet) GetFloat32Slice(name string) ([]float32, error) {
 	val, err := f.getFlagType(name, "float32Slice")
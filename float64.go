@@ -6,28 +6,31 @@ package zflag
 import "strconv"
 
 // -- float64 Value
-type float64Value float64
+type float64Value struct {
+	numberParser
+	value *float64
+}
 
 func newFloat64Value(val float64, p *float64) *float64Value {
 	*p = val
-	return (*float64Value)(p)
+	return &float64Value{value: p}
 }
 
 func (f *float64Value) Set(s string) error {
-	v, err := strconv.ParseFloat(s, 64)
-	*f = float64Value(v)
+	v, err := f.parseFloat(s, 64)
+	*f.value = float64(v)
 	return err
 }
 
 func (s *float64Value) Get() interface{} {
-	return float64(*s)
+	return *s.value
 }
 
 func (f *float64Value) Type() string {
 	return "float64"
 }
 
-func (f *float64Value) String() string { return strconv.FormatFloat(float64(*f), 'g', -1, 64) }
+func (f *float64Value) String() string { return strconv.FormatFloat(*f.value, 'g', -1, 64) }
 
 // GetFloat64 return the float64 value of a flag with the given name
 func (f *FlagSet) GetFloat64(name string) (float64, error) {
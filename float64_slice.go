@@ -5,12 +5,13 @@ package zflag
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 )
 
 // -- float64Slice Value
 type float64SliceValue struct {
+	numberParser
+	sliceLimits
 	value   *[]float64
 	changed bool
 }
@@ -31,11 +32,10 @@ func (s *float64SliceValue) Set(val string) error {
 	out := make([]float64, len(ss))
 	for i, d := range ss {
 		var err error
-		out[i], err = strconv.ParseFloat(d, 64)
+		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
-
 	}
 	if !s.changed {
 		*s.value = out
@@ -59,7 +59,7 @@ func (s *float64SliceValue) String() string {
 }
 
 func (s *float64SliceValue) fromString(val string) (float64, error) {
-	return strconv.ParseFloat(val, 64)
+	return s.parseFloat(val, 64)
 }
 
 func (s *float64SliceValue) toString(val float64) string {
@@ -67,6 +67,14 @@ func (s *float64SliceValue) toString(val float64) string {
 }
 
 func (s *float64SliceValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *float64SliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *float64SliceValue) appendValue(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
 		return err
@@ -75,13 +83,13 @@ func (s *float64SliceValue) Append(val string) error {
 	return nil
 }
 
-func (s *float64SliceValue) Replace(val []string) error {
+func (s *float64SliceValue) replaceValues(val []string) error {
 	out := make([]float64, len(val))
 	for i, d := range val {
 		var err error
 		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
 	}
 	*s.value = out
@@ -96,6 +104,11 @@ func (s *float64SliceValue) GetSlice() []string {
 	return out
 }
 
+func (s *float64SliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetFloat64Slice return the []float64 value of a flag with the given name
 func (f *FlagSet) GetFloat64Slice(name string) ([]float64, error) {
 	val, err := f.getFlagType(name, "float64Slice")
@@ -17,6 +17,9 @@ type FlagUsageFormatter interface {
 type DefaultFlagUsageFormatter struct{}
 
 var _ FlagUsageFormatter = (*DefaultFlagUsageFormatter)(nil)
+var _ GroupHeaderFormatter = (*DefaultFlagUsageFormatter)(nil)
+var _ ExperimentalFormatter = (*DefaultFlagUsageFormatter)(nil)
+var _ ConstraintFormatter = (*DefaultFlagUsageFormatter)(nil)
 
 func (d DefaultFlagUsageFormatter) Name(flag *Flag) string {
 	name := "  "
@@ -72,5 +75,17 @@ func (d DefaultFlagUsageFormatter) NoOptDefValue(flag *Flag) string {
 }
 
 func (d DefaultFlagUsageFormatter) Deprecated(flag *Flag) string {
-	return fmt.Sprintf(" (DEPRECATED: %s)", flag.Deprecated)
+	return fmt.Sprintf(" (DEPRECATED: %s)", flag.DeprecationNote())
+}
+
+func (d DefaultFlagUsageFormatter) Constraint(flag *Flag) string {
+	return fmt.Sprintf(" (%s)", flag.Constraint())
+}
+
+func (d DefaultFlagUsageFormatter) Experimental(flag *Flag) string {
+	return fmt.Sprintf(" (EXPERIMENTAL: %s)", flag.Experimental)
+}
+
+func (d DefaultFlagUsageFormatter) GroupHeader(title string) string {
+	return title + ":"
 }
@@ -0,0 +1,79 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"io"
+	"strings"
+)
+
+// GoFlagUsageFormatter formats flag names the way Go's flag package does,
+// i.e. with a single dash. It is meant to be used together with
+// GoHelpRenderer, see FlagSet.SetGoUsageLayout.
+type GoFlagUsageFormatter struct {
+	DefaultFlagUsageFormatter
+}
+
+var _ FlagUsageFormatter = (*GoFlagUsageFormatter)(nil)
+
+func (d GoFlagUsageFormatter) Name(flag *Flag) string {
+	name := "  "
+	if flag.Shorthand != 0 && flag.ShorthandDeprecated == "" {
+		name += "-" + string(flag.Shorthand)
+		if flag.ShorthandOnly {
+			return name
+		}
+		name += ", "
+	}
+
+	return name + "-" + flag.Name
+}
+
+// GoHelpRenderer renders the usage message in the layout of Go's
+// flag.PrintDefaults: the name and type on one line and the usage indented on
+// the next, except for flags whose name column is a single byte, which get
+// their usage on the same line. Output is never wrapped.
+type GoHelpRenderer struct{}
+
+var _ HelpRenderer = (*GoHelpRenderer)(nil)
+
+func (r GoHelpRenderer) Render(w io.Writer, model *HelpModel, cols int) error {
+	for i, group := range model.Groups {
+		if err := renderGroupHeader(w, i, group, cols); err != nil {
+			return err
+		}
+		for _, row := range group.Rows {
+			var b strings.Builder
+			b.WriteString(row.NameColumn)
+			// Boolean flags of one ASCII letter are so common that Go's
+			// flag package puts their usage on the same line.
+			if b.Len() <= 4 { // space, space, '-', 'x'.
+				b.WriteString("\t")
+			} else {
+				// Four spaces before the tab triggers good alignment
+				// for both 4- and 8-space tab stops.
+				b.WriteString("\n    \t")
+			}
+			b.WriteString(strings.Replace(row.Usage, "\n", "\n    \t", -1))
+			b.WriteString(row.Constraint)
+			b.WriteString(row.DefaultValue)
+			b.WriteString(row.Experimental)
+			b.WriteString(row.Deprecated)
+			b.WriteString("\n")
+
+			if _, err := io.WriteString(w, b.String()); err != nil {
+				return err
+			}
+		}
+	}
+	return renderFooter(w, model, cols)
+}
+
+// SetGoUsageLayout makes the FlagSet print its usage message byte for byte
+// like Go's flag package would for the same flags. It sets both the
+// FlagUsageFormatter and the HelpRenderer.
+func (f *FlagSet) SetGoUsageLayout() {
+	f.FlagUsageFormatter = GoFlagUsageFormatter{}
+	f.HelpRenderer = GoHelpRenderer{}
+}
@@ -0,0 +1,119 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	goflag "flag"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type goCustomValue int
+
+func (cv *goCustomValue) String() string { return strconv.Itoa(int(*cv)) }
+
+func (cv *goCustomValue) Set(s string) error {
+	v, err := strconv.Atoi(s)
+	*cv = goCustomValue(v)
+	return err
+}
+
+const goUsageOutput = `  -A	for bootstrapping, allow 'any' type
+  -Alongflagname
+    	disable bounds checking
+  -C	a boolean defaulting to true (default true)
+  -D path
+    	set relative path for local imports
+  -E string
+    	issue 23543 (default "0")
+  -F number
+    	a non-zero number (default 2.7)
+  -G float
+    	a float that defaults to zero
+  -M string
+    	a multiline
+    	help
+    	string
+  -N int
+    	a non-zero int (default 27)
+  -O	a flag
+    	multiline help string (default true)
+  -V value
+    	a custom Value implementation
+  -Z int
+    	an int that defaults to zero
+  -maxT timeout
+    	set timeout for dial
+  -u uint
+    	an unsigned int (default 7)
+  -w duration
+    	a duration (default 1s)
+`
+
+func TestGoUsageLayout(t *testing.T) {
+	var goBuf, buf bytes.Buffer
+
+	gfs := goflag.NewFlagSet("golden", goflag.ContinueOnError)
+	gfs.SetOutput(&goBuf)
+	gfs.Bool("A", false, "for bootstrapping, allow 'any' type")
+	gfs.Bool("Alongflagname", false, "disable bounds checking")
+	gfs.Bool("C", true, "a boolean defaulting to true")
+	gfs.String("D", "", "set relative `path` for local imports")
+	gfs.String("E", "0", "issue 23543")
+	gfs.Float64("F", 2.7, "a non-zero `number`")
+	gfs.Float64("G", 0, "a float that defaults to zero")
+	gfs.String("M", "", "a multiline\nhelp\nstring")
+	gfs.Int("N", 27, "a non-zero int")
+	gfs.Bool("O", true, "a flag\nmultiline help string")
+	gfs.Var(new(goCustomValue), "V", "a custom Value implementation")
+	gfs.Int64("Z", 0, "an int that defaults to zero")
+	gfs.Duration("maxT", 0, "set `timeout` for dial")
+	gfs.Uint64("u", 7, "an unsigned int")
+	gfs.Duration("w", time.Second, "a duration")
+	gfs.PrintDefaults()
+
+	fs := NewFlagSet("golden", ContinueOnError)
+	fs.SetOutput(&buf)
+	fs.SetGoUsageLayout()
+	fs.Bool("A", false, "for bootstrapping, allow 'any' type")
+	fs.Bool("Alongflagname", false, "disable bounds checking")
+	fs.Bool("C", true, "a boolean defaulting to true")
+	fs.String("D", "", "set relative `path` for local imports")
+	fs.String("E", "0", "issue 23543")
+	fs.Float64("F", 2.7, "a non-zero `number`")
+	fs.Float64("G", 0, "a float that defaults to zero")
+	fs.String("M", "", "a multiline\nhelp\nstring")
+	fs.Int("N", 27, "a non-zero int")
+	fs.Bool("O", true, "a flag\nmultiline help string")
+	fs.Var(new(goCustomValue), "V", "a custom Value implementation")
+	fs.Int64("Z", 0, "an int that defaults to zero")
+	fs.Duration("maxT", 0, "set `timeout` for dial")
+	fs.Uint64("u", 7, "an unsigned int")
+	fs.Duration("w", time.Second, "a duration")
+	fs.PrintDefaults()
+
+	if goBuf.String() != goUsageOutput {
+		t.Fatalf("golden output of Go's flag package changed:\n%q", goBuf.String())
+	}
+	if got := buf.String(); got != goBuf.String() {
+		t.Errorf("expected\n%s\ngot\n%s", goBuf.String(), got)
+	}
+}
+
+func TestGoUsageLayoutShorthand(t *testing.T) {
+	var buf bytes.Buffer
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetOutput(&buf)
+	fs.SetGoUsageLayout()
+	fs.Bool("verbose", false, "verbose output", OptShorthand('v'))
+	fs.Bool("quiet", false, "quiet output", OptShorthand('q'), OptShorthandOnly())
+
+	fs.PrintDefaults()
+	want := "  -q\tquiet output\n  -v, -verbose\n    \tverbose output\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q; got %q", want, got)
+	}
+}
@@ -0,0 +1,107 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"sort"
+)
+
+// FlagGroup holds the metadata of a flag group, used when printing the usage
+// message. Flags are assigned to a group by its ID with OptGroup.
+type FlagGroup struct {
+	ID          string // the group name used in Flag.Group
+	Title       string // title printed above the flags of the group, the ID if empty
+	Description string // optional text printed below the title
+	Order       int    // groups are printed in ascending order
+	Hidden      bool   // hide all flags of the group from help/usage text
+}
+
+// AddGroup registers the metadata of the flag group id and returns it, so
+// that it can be adjusted further (e.g. to hide the group). Groups are
+// printed in ascending order; groups with the same order are printed in the
+// order they were added. Registering a group that already exists replaces its
+// metadata but keeps its position among groups with the same order.
+func (f *FlagSet) AddGroup(id, title, description string, order int) *FlagGroup {
+	if f.groups == nil {
+		f.groups = make(map[string]*FlagGroup)
+	}
+
+	group, ok := f.groups[id]
+	if !ok {
+		group = &FlagGroup{ID: id}
+		f.groups[id] = group
+		f.orderedGroups = append(f.orderedGroups, group)
+	}
+	group.Title = title
+	group.Description = description
+	group.Order = order
+
+	return group
+}
+
+// LookupGroup returns the metadata of the flag group id, returning nil if it
+// was not registered with AddGroup.
+func (f *FlagSet) LookupGroup(id string) *FlagGroup {
+	return f.groups[id]
+}
+
+// groupTitle returns the title of the group id.
+func (f *FlagSet) groupTitle(id string) string {
+	if group := f.groups[id]; group != nil && group.Title != "" {
+		return group.Title
+	}
+	return id
+}
+
+// isHiddenFlag reports whether flag is left out of help/usage text.
+func (f *FlagSet) isHiddenFlag(flag *Flag) bool {
+	return f.isHiddenByDefinition(flag) || f.isGatedFlag(flag)
+}
+
+// isHiddenByDefinition reports whether flag or its group is hidden.
+func (f *FlagSet) isHiddenByDefinition(flag *Flag) bool {
+	if flag.Hidden {
+		return true
+	}
+	if group := f.groups[flag.Group]; group != nil && group.Hidden {
+		return true
+	}
+	return false
+}
+
+// sortGroups sorts the group ids, which are expected to be ordered
+// alphabetically with the empty group first, by their registered order.
+// Registered groups keep the order they were added in, groups without
+// metadata come after registered ones with the same order.
+func (f *FlagSet) sortGroups(groups []string) {
+	rank := make(map[string]int, len(groups))
+	for i, group := range f.orderedGroups {
+		rank[group.ID] = i
+	}
+	for i, id := range groups {
+		if _, ok := rank[id]; ok {
+			continue
+		}
+		if id == "" {
+			rank[id] = -1
+		} else {
+			rank[id] = len(f.orderedGroups) + i
+		}
+	}
+
+	order := func(id string) int {
+		if group := f.groups[id]; group != nil {
+			return group.Order
+		}
+		return 0
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		oi, oj := order(groups[i]), order(groups[j])
+		if oi != oj {
+			return oi < oj
+		}
+		return rank[groups[i]] < rank[groups[j]]
+	})
+}
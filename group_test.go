@@ -0,0 +1,103 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func setUpGroupFlagSet(buf *bytes.Buffer) *FlagSet {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetOutput(buf)
+	fs.Bool("verbose", false, "verbose output", OptShorthand('v'))
+	fs.String("output", "", "output `file`", OptShorthand('o'), OptGroup("io"))
+	fs.String("input", "", "input `file`", OptShorthand('i'), OptGroup("io"))
+	fs.Int("workers", 4, "number of workers", OptGroup("advanced"))
+	fs.Bool("trace", false, "trace execution", OptGroup("debug"))
+	return fs
+}
+
+func TestGroupOrder(t *testing.T) {
+	var buf bytes.Buffer
+	fs := setUpGroupFlagSet(&buf)
+
+	want := []string{"", "advanced", "debug", "io"}
+	if got := fs.Groups(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v; got %v", want, got)
+	}
+
+	fs.AddGroup("io", "Input/Output", "", 0)
+	fs.AddGroup("advanced", "Advanced", "", 0)
+	want = []string{"", "io", "advanced", "debug"}
+	if got := fs.Groups(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v; got %v", want, got)
+	}
+
+	fs.AddGroup("", "General", "", 1)
+	fs.AddGroup("debug", "Debugging", "", -1)
+	want = []string{"debug", "io", "advanced", ""}
+	if got := fs.Groups(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v; got %v", want, got)
+	}
+}
+
+const groupedUsage = `Usage: test [-hv] [-i file] [-o file] [--workers int]
+
+  -v, --verbose       verbose output
+
+Input/Output:
+  Where data is read from and written to.
+  -i, --input file    input file
+  -o, --output file   output file
+
+advanced:
+      --workers int   number of workers (default 4)
+`
+
+func TestGroupedUsage(t *testing.T) {
+	var buf bytes.Buffer
+	fs := setUpGroupFlagSet(&buf)
+	fs.AddGroup("io", "Input/Output", "Where data is read from and written to.", 0)
+	fs.AddGroup("debug", "Debugging", "", 0).Hidden = true
+
+	fs.defaultUsage()
+	if got := buf.String(); got != groupedUsage {
+		t.Errorf("expected\n%s\ngot\n%s", groupedUsage, got)
+	}
+
+	if got, want := fs.FlagUsagesForGroup("io"), "  -i, --input file    input file\n  -o, --output file   output file\n"; got != want {
+		t.Errorf("expected group usage without header %q; got %q", want, got)
+	}
+}
+
+func TestGroupedUsageUnsorted(t *testing.T) {
+	var buf bytes.Buffer
+	fs := setUpGroupFlagSet(&buf)
+	fs.SortFlags = false
+
+	model := fs.HelpModel()
+	rows := model.Group("io").Rows
+	if rows[0].Flag.Name != "output" || rows[1].Flag.Name != "input" {
+		t.Errorf("expected flags in definition order; got %s, %s", rows[0].Flag.Name, rows[1].Flag.Name)
+	}
+}
+
+func TestGroupDescriptionWrapped(t *testing.T) {
+	var buf bytes.Buffer
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetOutput(&buf)
+	fs.Bool("trace", false, "trace execution", OptGroup("debug"))
+	fs.AddGroup("debug", "Debugging", "These flags are meant for developers and may change without notice in any release.", 0)
+
+	want := `Debugging:
+  These flags are meant for developers and may change without
+  notice in any release.
+      --trace   trace execution
+`
+	if got := fs.GroupedFlagUsagesWrapped(70); got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
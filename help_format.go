@@ -0,0 +1,190 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+)
+
+// HelpFormatFunc writes the help for the FlagSet f to w in a specific format.
+// It is invoked by the built-in help handling when --help=<format> is passed.
+type HelpFormatFunc func(f *FlagSet, w io.Writer) error
+
+var (
+	helpFormatsMu sync.RWMutex
+	helpFormats   = map[string]HelpFormatFunc{
+		"json": printHelpJSON,
+	}
+)
+
+// RegisterHelpFormat makes the help format available as --help=<name> for
+// every FlagSet with the built-in help enabled. Registering a format with an
+// existing name replaces it. Passing a nil fn removes the format.
+func RegisterHelpFormat(name string, fn HelpFormatFunc) {
+	helpFormatsMu.Lock()
+	defer helpFormatsMu.Unlock()
+
+	if fn == nil {
+		delete(helpFormats, name)
+		return
+	}
+	helpFormats[name] = fn
+}
+
+// LookupHelpFormat returns the help format registered under name, or nil if
+// none exists.
+func LookupHelpFormat(name string) HelpFormatFunc {
+	helpFormatsMu.RLock()
+	defer helpFormatsMu.RUnlock()
+
+	return helpFormats[name]
+}
+
+// HelpFormats returns the names of all registered help formats in sorted order.
+func HelpFormats() []string {
+	helpFormatsMu.RLock()
+	defer helpFormatsMu.RUnlock()
+
+	names := make([]string, 0, len(helpFormats))
+	for name := range helpFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HelpDocument is the structured representation of a FlagSet printed by
+// --help=json.
+type HelpDocument struct {
+	Name  string     `json:"name"`
+	Flags []HelpFlag `json:"flags"`
+}
+
+// HelpFlag is the structured representation of a single flag in a HelpDocument.
+type HelpFlag struct {
+	Name                string              `json:"name"`
+	Shorthand           string              `json:"shorthand,omitempty"`
+	ShorthandOnly       bool                `json:"shorthandOnly,omitempty"`
+	Type                string              `json:"type,omitempty"`
+	UsageType           string              `json:"usageType,omitempty"`
+	Usage               string              `json:"usage"`
+	LongUsage           string              `json:"longUsage,omitempty"`
+	Examples            []string            `json:"examples,omitempty"`
+	Env                 []string            `json:"env,omitempty"`
+	Choices             []string            `json:"choices,omitempty"`
+	Constraint          string              `json:"constraint,omitempty"`
+	Default             string              `json:"default"`
+	Value               string              `json:"value"`
+	Changed             bool                `json:"changed"`
+	NoOptDefVal         string              `json:"noOptDefVal,omitempty"`
+	Group               string              `json:"group,omitempty"`
+	Visibility          string              `json:"visibility"`
+	Implies             map[string]string   `json:"implies,omitempty"`
+	ImpliedBy           []string            `json:"impliedBy,omitempty"`
+	Experimental        string              `json:"experimental,omitempty"`
+	Deprecated          string              `json:"deprecated,omitempty"`
+	DeprecatedSince     string              `json:"deprecatedSince,omitempty"`
+	RemovedIn           string              `json:"removedIn,omitempty"`
+	ReplacedBy          string              `json:"replacedBy,omitempty"`
+	ShorthandDeprecated string              `json:"shorthandDeprecated,omitempty"`
+	Annotations         map[string][]string `json:"annotations,omitempty"`
+}
+
+// HelpDocument returns the structured representation of all flags in the
+// FlagSet that are not hidden, in the same order as VisitAll. Flags of every
+// Visibility are included.
+func (f *FlagSet) HelpDocument() HelpDocument {
+	doc := HelpDocument{
+		Name:  f.name,
+		Flags: make([]HelpFlag, 0, len(f.formal)),
+	}
+
+	f.VisitAll(func(flag *Flag) {
+		if f.isHiddenFlag(flag) {
+			return
+		}
+
+		varname, usage := UnquoteUsage(flag)
+		hf := HelpFlag{
+			Name:                flag.Name,
+			ShorthandOnly:       flag.ShorthandOnly,
+			UsageType:           varname,
+			Usage:               usage,
+			LongUsage:           flag.LongUsage,
+			Examples:            flag.Examples,
+			Env:                 flag.EnvVars,
+			Constraint:          flag.Constraint(),
+			Default:             flag.DefValue,
+			Value:               flag.Value.String(),
+			Changed:             flag.Changed,
+			NoOptDefVal:         flag.NoOptDefVal,
+			Group:               flag.Group,
+			Visibility:          flag.Visibility.String(),
+			Implies:             flag.Implies,
+			ImpliedBy:           f.ImpliedBy(flag.Name),
+			Experimental:        string(flag.Experimental),
+			Deprecated:          flag.DeprecationNote(),
+			DeprecatedSince:     flag.DeprecatedSince,
+			RemovedIn:           flag.RemovedIn,
+			ReplacedBy:          flag.ReplacedBy,
+			ShorthandDeprecated: flag.ShorthandDeprecated,
+			Annotations:         flag.Annotations,
+		}
+		if flag.Shorthand != 0 {
+			hf.Shorthand = string(flag.Shorthand)
+		}
+		if v, ok := flag.Value.(Typed); ok {
+			hf.Type = v.Type()
+		}
+		if e, ok := flag.Value.(enumValuer); ok {
+			hf.Choices = e.choiceSet().values()
+		}
+
+		doc.Flags = append(doc.Flags, hf)
+	})
+
+	return doc
+}
+
+func printHelpJSON(f *FlagSet, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(f.HelpDocument())
+}
+
+// printHelp handles the built-in help flags. The name of a flag prints the
+// detailed help of that flag; flag names take precedence over the names below,
+// so a flag named e.g. "json" is looked up as a flag. An empty format or a
+// Visibility name prints the regular usage message showing flags up to that
+// visibility. Anything else is looked up in the help format registry.
+func (f *FlagSet) printHelp(format string, level Visibility) error {
+	if format != "" {
+		if flag := f.lookupHelpTopic(format); flag != nil {
+			return f.printFlagHelp(flag)
+		}
+	}
+	if v, ok := parseVisibility(format); ok {
+		level = v
+		format = ""
+	}
+
+	if format == "" {
+		defer func(previous Visibility) { f.HelpVisibility = previous }(f.HelpVisibility)
+		f.HelpVisibility = level
+		f.usage()
+		return ErrHelp
+	}
+
+	fn := LookupHelpFormat(format)
+	if fn == nil {
+		return f.failf(ErrorInvalidValue, nil, "unknown help topic %q, expected a flag name or one of %v", format, HelpFormats())
+	}
+	if err := fn(f, f.Output()); err != nil {
+		return err
+	}
+	return ErrHelp
+}
@@ -0,0 +1,90 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestHelpJSON(t *testing.T) {
+	var buf bytes.Buffer
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetOutput(&buf)
+	usageCalled := false
+	fs.Usage = func() { usageCalled = true }
+	fs.Int("port", 8080, "listen `port`", OptShorthand('p'))
+	fs.Bool("verbose", false, "verbose output")
+	fs.String("secret", "", "hidden flag", OptHidden())
+
+	err := fs.Parse([]string{"--port=9090", "--help=json"})
+	if err != ErrHelp {
+		t.Fatalf("expected ErrHelp; got %v", err)
+	}
+	if usageCalled {
+		t.Fatal("usage should not be called for --help=json")
+	}
+
+	var doc HelpDocument
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON output %q: %v", buf.String(), err)
+	}
+	if doc.Name != "test" {
+		t.Errorf("expected name %q; got %q", "test", doc.Name)
+	}
+	if len(doc.Flags) != 2 {
+		t.Fatalf("expected 2 flags; got %d", len(doc.Flags))
+	}
+
+	port := doc.Flags[0]
+	if port.Name != "port" || port.Shorthand != "p" || port.Type != "int" || port.UsageType != "port" {
+		t.Errorf("unexpected port flag: %+v", port)
+	}
+	if port.Default != "8080" || port.Value != "9090" || !port.Changed {
+		t.Errorf("unexpected port values: %+v", port)
+	}
+	if port.Usage != "listen port" {
+		t.Errorf("expected unquoted usage; got %q", port.Usage)
+	}
+}
+
+func TestHelpFormatUnknown(t *testing.T) {
+	var buf bytes.Buffer
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetOutput(&buf)
+
+	err := fs.Parse([]string{"--help=xml"})
+	if err == nil || err == ErrHelp {
+		t.Fatalf("expected unknown format error; got %v", err)
+	}
+}
+
+func TestRegisterHelpFormat(t *testing.T) {
+	RegisterHelpFormat("names", func(fs *FlagSet, w io.Writer) error {
+		fs.VisitAll(func(f *Flag) { fmt.Fprintln(w, f.Name) })
+		return nil
+	})
+	defer RegisterHelpFormat("names", nil)
+
+	var buf bytes.Buffer
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetOutput(&buf)
+	fs.Bool("a", false, "")
+	fs.Bool("b", false, "")
+
+	if err := fs.Parse([]string{"--help=names"}); err != ErrHelp {
+		t.Fatalf("expected ErrHelp; got %v", err)
+	}
+	if got, want := buf.String(), "a\nb\n"; got != want {
+		t.Errorf("expected %q; got %q", want, got)
+	}
+
+	RegisterHelpFormat("names", nil)
+	if LookupHelpFormat("names") != nil {
+		t.Error("expected format to be removed")
+	}
+}
@@ -0,0 +1,230 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// HelpModel is the intermediate representation of the usage message of a
+// FlagSet. All fields are computed with the FlagSet's FlagUsageFormatter, so
+// renderers only need to take care of the layout.
+type HelpModel struct {
+	// NameWidth is the display width of the widest NameColumn across all groups.
+	NameWidth int
+	// Groups holds the flags of the FlagSet, in the same order as Groups().
+	Groups []HelpGroup
+	// Footer is a note printed below all groups, e.g. on the number of flags
+	// not shown because of their Visibility.
+	Footer string
+}
+
+// HelpGroup is a group of flags in a HelpModel.
+type HelpGroup struct {
+	// Name is the group name, empty for flags without a group.
+	Name string
+	// Title is the title of the group, see AddGroup. It is empty for flags
+	// without a group, unless the empty group was added with a title.
+	Title string
+	// Header is the formatted Title, empty if no header should be printed.
+	Header string
+	// Description is the description of the group, see AddGroup.
+	Description string
+	Rows        []HelpRow
+}
+
+// HelpRow holds the formatted fields of a single flag in a HelpModel.
+type HelpRow struct {
+	Flag *Flag
+
+	// NameColumn is the complete left-hand column, i.e. Name, VarName and
+	// NoOptDefVal joined together.
+	NameColumn string
+	// Name is the formatted flag name, including the shorthand.
+	Name string
+	// VarName is the formatted type or back-quoted name from the usage, if any.
+	VarName string
+	// NoOptDefVal is the formatted value used when the flag has no argument, if any.
+	NoOptDefVal string
+
+	// UsageColumn is the complete right-hand column, i.e. Usage, Constraint,
+	// DefaultValue, Experimental and Deprecated joined together.
+	UsageColumn string
+	// Usage is the formatted usage message, with back quotes removed.
+	Usage string
+	// Constraint is the formatted constraint of the validators, if any.
+	Constraint string
+	// DefaultValue is the formatted default value, empty if it should not be printed.
+	DefaultValue string
+	// Experimental is the formatted stage, empty if the flag is not experimental.
+	Experimental string
+	// Deprecated is the formatted deprecation message, empty if the flag is not deprecated.
+	Deprecated string
+}
+
+// Group returns the group with the given name, or nil if no visible flag is
+// part of it.
+func (m *HelpModel) Group(name string) *HelpGroup {
+	for i := range m.Groups {
+		if m.Groups[i].Name == name {
+			return &m.Groups[i]
+		}
+	}
+	return nil
+}
+
+// HelpRenderer renders a HelpModel.
+type HelpRenderer interface {
+	// Render writes all groups of the model to w, wrapped to cols columns
+	// (0 for no wrapping).
+	Render(w io.Writer, model *HelpModel, cols int) error
+}
+
+// TextHelpRenderer is the default HelpRenderer. It prints one flag per line
+// with the usage column aligned across all groups. Groups are separated by an
+// empty line and start with their header and description, if any.
+type TextHelpRenderer struct{}
+
+var _ HelpRenderer = (*TextHelpRenderer)(nil)
+
+func (r TextHelpRenderer) Render(w io.Writer, model *HelpModel, cols int) error {
+	for i, group := range model.Groups {
+		if err := renderGroupHeader(w, i, group, cols); err != nil {
+			return err
+		}
+		for _, row := range group.Rows {
+			spacing := strings.Repeat(" ", model.NameWidth-displayWidth(row.NameColumn)+1)
+			// NameWidth + 3 comes from the spacing above and the two separators added by Fprintln
+			_, err := fmt.Fprintln(w, row.NameColumn, spacing, wrap(model.NameWidth+3, cols, row.UsageColumn))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return renderFooter(w, model, cols)
+}
+
+// renderFooter writes the footer of a HelpModel, separated from the flags by
+// an empty line.
+func renderFooter(w io.Writer, model *HelpModel, cols int) error {
+	if model.Footer == "" {
+		return nil
+	}
+	_, err := fmt.Fprintf(w, "\n%s\n", wrap(0, cols, model.Footer))
+	return err
+}
+
+// renderGroupHeader writes the separator, header and description of the i-th
+// group of a HelpModel.
+func renderGroupHeader(w io.Writer, i int, group HelpGroup, cols int) error {
+	if i > 0 {
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	if group.Header != "" {
+		if _, err := fmt.Fprintln(w, group.Header); err != nil {
+			return err
+		}
+	}
+	if group.Description != "" {
+		if _, err := fmt.Fprintln(w, "  "+wrap(2, cols, group.Description)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *FlagSet) helpRenderer() HelpRenderer {
+	if f.HelpRenderer == nil {
+		return TextHelpRenderer{}
+	}
+
+	return f.HelpRenderer
+}
+
+// HelpModel returns the intermediate representation of the usage message of
+// all flags in the FlagSet that are not hidden, either by themselves or by
+// their group, and whose Visibility is at most HelpVisibility.
+func (f *FlagSet) HelpModel() *HelpModel {
+	model := &HelpModel{}
+	usageFormatter := f.flagUsageFormatter()
+
+	rows := make(map[string][]HelpRow)
+	leftOut := make(map[Visibility]int)
+	gated := 0
+	f.VisitAll(func(flag *Flag) {
+		if f.isHiddenFlag(flag) {
+			if !f.isHiddenByDefinition(flag) && flag.Visibility <= f.HelpVisibility {
+				gated++
+			}
+			return
+		}
+		if flag.Visibility > f.HelpVisibility {
+			leftOut[flag.Visibility]++
+			return
+		}
+
+		row := HelpRow{
+			Flag: flag,
+			Name: usageFormatter.Name(flag),
+		}
+
+		varname, usage := UnquoteUsage(flag)
+		row.NameColumn = row.Name
+		if varname != "" {
+			row.VarName = usageFormatter.UsageVarName(flag, varname)
+			row.NameColumn += " " + row.VarName
+		}
+		if flag.NoOptDefVal != "" {
+			row.NoOptDefVal = usageFormatter.NoOptDefValue(flag)
+			row.NameColumn += row.NoOptDefVal
+		}
+		if w := displayWidth(row.NameColumn); w > model.NameWidth {
+			model.NameWidth = w
+		}
+
+		row.Usage = usageFormatter.Usage(flag, usage)
+		if flag.Constraint() != "" {
+			row.Constraint = constraintNote(usageFormatter, flag)
+		}
+		if !flag.DisablePrintDefault && !flag.defaultIsZeroValue() {
+			row.DefaultValue = usageFormatter.DefaultValue(flag)
+		}
+		if flag.Experimental != "" {
+			row.Experimental = experimentalNote(usageFormatter, flag)
+		}
+		if flag.IsDeprecated() {
+			row.Deprecated = usageFormatter.Deprecated(flag)
+		}
+		row.UsageColumn = row.Usage + row.Constraint + row.DefaultValue + row.Experimental + row.Deprecated
+
+		rows[flag.Group] = append(rows[flag.Group], row)
+	})
+
+	for _, group := range f.Groups() {
+		if len(rows[group]) == 0 {
+			continue
+		}
+		g := HelpGroup{Name: group, Title: f.groupTitle(group), Rows: rows[group]}
+		if meta := f.groups[group]; meta != nil {
+			g.Description = meta.Description
+		}
+		if g.Title != "" {
+			g.Header = g.Title + ":"
+			if h, ok := usageFormatter.(GroupHeaderFormatter); ok {
+				g.Header = h.GroupHeader(g.Title)
+			}
+		}
+		model.Groups = append(model.Groups, g)
+	}
+	model.Footer = f.helpFooter(leftOut)
+	if note := f.experimentalFooter(gated); note != "" {
+		model.Footer = strings.TrimSpace(model.Footer + " " + note)
+	}
+
+	return model
+}
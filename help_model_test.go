@@ -0,0 +1,75 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestHelpModel(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.String("name", "bob", "the `who` to greet", OptShorthand('n'))
+	fs.Int("level", 0, "level", OptNoOptDefVal("3"), OptGroup("advanced"))
+	fs.Bool("old", false, "old flag", OptDeprecated("use --name"))
+	fs.Bool("secret", false, "secret", OptHidden())
+
+	model := fs.HelpModel()
+	if len(model.Groups) != 2 {
+		t.Fatalf("expected 2 groups; got %d", len(model.Groups))
+	}
+	if model.Group("") == nil || model.Group("advanced") == nil {
+		t.Fatalf("unexpected groups: %+v", model.Groups)
+	}
+
+	rows := model.Group("").Rows
+	if len(rows) != 1 {
+		t.Fatalf("expected hidden and deprecated flags to be skipped; got %d rows", len(rows))
+	}
+	row := rows[0]
+	if row.NameColumn != "  -n, --name who" || row.VarName != "who" {
+		t.Errorf("unexpected name column: %+v", row)
+	}
+	if row.Usage != "the who to greet" || row.DefaultValue != ` (default "bob")` {
+		t.Errorf("unexpected usage column: %+v", row)
+	}
+	if row.UsageColumn != `the who to greet (default "bob")` {
+		t.Errorf("unexpected usage column %q", row.UsageColumn)
+	}
+
+	level := model.Group("advanced").Rows[0]
+	if level.NoOptDefVal != "[=3]" || level.DefaultValue != "" {
+		t.Errorf("unexpected level row: %+v", level)
+	}
+	if model.NameWidth != len(level.NameColumn) {
+		t.Errorf("expected name width %d; got %d", len(level.NameColumn), model.NameWidth)
+	}
+}
+
+type twoLineRenderer struct{}
+
+func (r twoLineRenderer) Render(w io.Writer, model *HelpModel, cols int) error {
+	for _, group := range model.Groups {
+		for _, row := range group.Rows {
+			fmt.Fprintf(w, "%s\n    %s\n", row.NameColumn, row.UsageColumn)
+		}
+	}
+	return nil
+}
+
+func TestCustomHelpRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetOutput(&buf)
+	fs.HelpRenderer = twoLineRenderer{}
+	fs.Int("count", 1, "number of items", OptShorthand('c'))
+
+	fs.PrintDefaults()
+	want := "  -c, --count int\n    number of items (default 1)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expected %q; got %q", want, got)
+	}
+}
@@ -0,0 +1,166 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// impliesTrigger reports whether flag was set in a way that applies its
+// implications. Boolean flags only imply other values when they are true.
+func impliesTrigger(flag *Flag) bool {
+	if !flag.isSet() || len(flag.Implies) == 0 {
+		return false
+	}
+	if v, ok := flag.Value.(boolFlag); ok && v.IsBoolFlag() {
+		return flag.Value.String() == "true"
+	}
+	return true
+}
+
+// sortedImplies returns the names of the flags implied by flag, sorted.
+func sortedImplies(flag *Flag) []string {
+	names := make([]string, 0, len(flag.Implies))
+	for name := range flag.Implies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkImpliesCycles returns an error if the implications of the flags form a
+// cycle.
+func (f *FlagSet) checkImpliesCycles() error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[*Flag]int)
+	var path []string
+
+	var visit func(flag *Flag) error
+	visit = func(flag *Flag) error {
+		switch state[flag] {
+		case visiting:
+			return fmt.Errorf("flag implication cycle: --%s -> --%s", strings.Join(path, " -> --"), flag.Name)
+		case visited:
+			return nil
+		}
+		state[flag] = visiting
+		path = append(path, flag.Name)
+		for _, name := range sortedImplies(flag) {
+			if target := f.Lookup(name); target != nil {
+				if err := visit(target); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[flag] = visited
+		return nil
+	}
+
+	var err error
+	f.VisitAll(func(flag *Flag) {
+		if err == nil {
+			err = visit(flag)
+		}
+	})
+	return err
+}
+
+// errImpliedValue is returned when a flag implies an invalid value.
+type errImpliedValue struct {
+	flag   *Flag
+	target *Flag
+	err    error
+}
+
+func (e errImpliedValue) Error() string {
+	return fmt.Sprintf("flag --%s implies an invalid value: %v", e.flag.Name, e.err)
+}
+
+func (e errImpliedValue) Unwrap() error {
+	return e.err
+}
+
+// ApplyImplies sets the values implied by flags that were set, see
+// OptImplies. Implied values are only set on flags that were not set
+// otherwise, with SourceImplied, and can imply further values in turn. The
+// flags are not marked as Changed. If several flags imply a value for the same
+// flag, the first one wins: the flags that were set in the order of VisitAll,
+// then the flags that were implied themselves. It is called by Parse after the
+// command line was parsed and the presets were applied.
+func (f *FlagSet) ApplyImplies() error {
+	if err := f.checkImpliesCycles(); err != nil {
+		return err
+	}
+
+	var queue []*Flag
+	f.VisitAll(func(flag *Flag) {
+		if flag.Changed && impliesTrigger(flag) {
+			queue = append(queue, flag)
+		}
+	})
+
+	for len(queue) > 0 {
+		flag := queue[0]
+		queue = queue[1:]
+
+		for _, name := range sortedImplies(flag) {
+			target := f.Lookup(name)
+			if target == nil {
+				return fmt.Errorf("flag --%s implies unknown flag --%s", flag.Name, name)
+			}
+			if target.isSet() {
+				continue
+			}
+			if err := f.set(target, flag.Implies[name], SourceImplied); err != nil {
+				return errImpliedValue{flag: flag, target: target, err: err}
+			}
+			target.ImpliedBy = flag.Name
+			if impliesTrigger(target) {
+				queue = append(queue, target)
+			}
+		}
+	}
+	return nil
+}
+
+// applyImplies is ApplyImplies for parsing, presenting errors as configured.
+func (f *FlagSet) applyImplies() error {
+	if err := f.ApplyImplies(); err != nil {
+		var implied errImpliedValue
+		if errors.As(err, &implied) {
+			return f.fail(&ParseError{Category: valueErrorCategory(implied.err), Flag: implied.target, Err: err})
+		}
+		return f.fail(&ParseError{Category: ErrorOther, Err: err})
+	}
+	return nil
+}
+
+// ImpliedBy returns the names of the flags that imply a value for the named
+// flag, sorted.
+func (f *FlagSet) ImpliedBy(name string) []string {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return nil
+	}
+
+	var names []string
+	f.VisitAll(func(other *Flag) {
+		for implied := range other.Implies {
+			if f.Lookup(implied) == flag {
+				names = append(names, other.Name)
+				return
+			}
+		}
+	})
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,142 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"strings"
+	"testing"
+)
+
+func setUpImpliesFlagSet() *FlagSet {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.Bool("production", false, "run in production", OptImplies(map[string]string{"log-format": "json", "debug": "false", "strict": "true"}))
+	fs.String("log-format", "text", "log format")
+	fs.Bool("debug", true, "debug mode")
+	fs.Bool("strict", false, "strict mode", OptImplies(map[string]string{"retries": "0"}))
+	fs.Int("retries", 3, "number of retries")
+	fs.SetErrorPresentation(ErrorSilent)
+	return fs
+}
+
+func TestImplies(t *testing.T) {
+	fs := setUpImpliesFlagSet()
+	if err := fs.Parse([]string{"--production", "--debug"}); err != nil {
+		t.Fatal(err)
+	}
+	if v := fs.MustGetString("log-format"); v != "json" {
+		t.Errorf("expected the implied value; got %q", v)
+	}
+	if !fs.MustGetBool("debug") {
+		t.Error("expected the explicit value to win")
+	}
+	if v := fs.MustGetInt("retries"); v != 0 {
+		t.Errorf("expected the chained implied value; got %d", v)
+	}
+
+	if p := fs.Lookup("log-format").Provenance(); p != "implied by --production" {
+		t.Errorf("unexpected provenance %q", p)
+	}
+	if p := fs.Lookup("retries").Provenance(); p != "implied by --strict" {
+		t.Errorf("unexpected provenance %q", p)
+	}
+	if p := fs.Lookup("debug").Provenance(); p != "command line" {
+		t.Errorf("unexpected provenance %q", p)
+	}
+
+	fs = setUpImpliesFlagSet()
+	if err := fs.Parse([]string{"--production=false"}); err != nil {
+		t.Fatal(err)
+	}
+	if fs.Lookup("log-format").Changed {
+		t.Error("expected false bool flags to imply nothing")
+	}
+}
+
+func TestImpliesErrors(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.Bool("a", false, "a", OptImplies(map[string]string{"b": "true"}))
+	fs.Bool("b", false, "b", OptImplies(map[string]string{"c": "true"}))
+	fs.Bool("c", false, "c", OptImplies(map[string]string{"a": "true"}))
+	fs.SetErrorPresentation(ErrorSilent)
+	err := fs.Parse(nil)
+	if err == nil || err.Error() != "flag implication cycle: --a -> --b -> --c -> --a" {
+		t.Errorf("expected a cycle error; got %v", err)
+	}
+
+	fs = NewFlagSet("test", ContinueOnError)
+	fs.Bool("a", false, "a", OptImplies(map[string]string{"missing": "true"}))
+	fs.SetErrorPresentation(ErrorSilent)
+	err = fs.Parse([]string{"--a"})
+	if err == nil || err.Error() != "flag --a implies unknown flag --missing" {
+		t.Errorf("expected an unknown flag error; got %v", err)
+	}
+
+	fs = NewFlagSet("test", ContinueOnError)
+	fs.Bool("a", false, "a", OptImplies(map[string]string{"n": "many"}))
+	fs.Int("n", 0, "n")
+	fs.SetErrorPresentation(ErrorSilent)
+	err = fs.Parse([]string{"--a"})
+	if err == nil || !strings.HasPrefix(err.Error(), `flag --a implies an invalid value: invalid argument "many" for "--n" flag`) {
+		t.Errorf("expected an invalid value error; got %v", err)
+	}
+	if ErrorCategoryOf(err) != ErrorInvalidValue {
+		t.Errorf("expected ErrorInvalidValue; got %v", ErrorCategoryOf(err))
+	}
+}
+
+func TestImpliesNotChanged(t *testing.T) {
+	fs := setUpImpliesFlagSet()
+	if err := fs.Parse([]string{"--production"}); err != nil {
+		t.Fatal(err)
+	}
+	if flag := fs.Lookup("log-format"); flag.Changed || flag.Source != SourceImplied {
+		t.Errorf("expected an implied value that is not changed; got %v", flag.Source)
+	}
+	var visited []string
+	fs.Visit(func(flag *Flag) { visited = append(visited, flag.Name) })
+	if strings.Join(visited, ",") != "production" {
+		t.Errorf("expected only the flags set by the user to be visited; got %v", visited)
+	}
+
+	if err := fs.SetWithSource("log-format", "yaml", SourcePreset); err != nil {
+		t.Fatal(err)
+	}
+	if flag := fs.Lookup("log-format"); flag.Value.String() != "yaml" || flag.Source != SourcePreset {
+		t.Errorf("expected other sources to override implied values; got %s from %v", flag.Value, flag.Source)
+	}
+}
+
+func TestImpliesOrder(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SortFlags = false
+	fs.Bool("z", false, "z", OptImplies(map[string]string{"mode": "z"}))
+	fs.Bool("a", false, "a", OptImplies(map[string]string{"mode": "a"}))
+	fs.String("mode", "", "mode")
+	if err := fs.Parse([]string{"--a", "--z"}); err != nil {
+		t.Fatal(err)
+	}
+	if p := fs.Lookup("mode").Provenance(); p != "implied by --z" {
+		t.Errorf("expected the first flag in the order of VisitAll to win; got %q", p)
+	}
+}
+
+func TestImpliesHelp(t *testing.T) {
+	fs := setUpImpliesFlagSet()
+	if impliedBy := fs.ImpliedBy("retries"); len(impliedBy) != 1 || impliedBy[0] != "strict" {
+		t.Errorf("unexpected implied by %v", impliedBy)
+	}
+
+	help, err := fs.FlagHelp("production", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(help, "    Implies:       --debug=false --log-format=json --strict=true\n") {
+		t.Errorf("expected the implications in the help; got:\n%s", help)
+	}
+
+	help, _ = fs.FlagHelp("log-format", 0)
+	if !strings.Contains(help, "    Implied by: --production\n") {
+		t.Errorf("expected the implying flags in the help; got:\n%s", help)
+	}
+}
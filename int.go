@@ -6,28 +6,31 @@ package zflag
 import "strconv"
 
 // -- int Value
-type intValue int
+type intValue struct {
+	numberParser
+	value *int
+}
 
 func newIntValue(val int, p *int) *intValue {
 	*p = val
-	return (*intValue)(p)
+	return &intValue{value: p}
 }
 
 func (i *intValue) Set(s string) error {
-	v, err := strconv.ParseInt(s, 0, 64)
-	*i = intValue(v)
+	v, err := i.parseInt(s, 0)
+	*i.value = int(v)
 	return err
 }
 
 func (i *intValue) Get() interface{} {
-	return int(*i)
+	return *i.value
 }
 
 func (i *intValue) Type() string {
 	return "int"
 }
 
-func (i *intValue) String() string { return strconv.Itoa(int(*i)) }
+func (i *intValue) String() string { return strconv.Itoa(*i.value) }
 
 // GetInt return the int value of a flag with the given name
 func (f *FlagSet) GetInt(name string) (int, error) {
@@ -6,28 +6,31 @@ package zflag
 import "strconv"
 
 // -- int16 Value
-type int16Value int16
+type int16Value struct {
+	numberParser
+	value *int16
+}
 
 func newInt16Value(val int16, p *int16) *int16Value {
 	*p = val
-	return (*int16Value)(p)
+	return &int16Value{value: p}
 }
 
 func (i *int16Value) Set(s string) error {
-	v, err := strconv.ParseInt(s, 0, 16)
-	*i = int16Value(v)
+	v, err := i.parseInt(s, 16)
+	*i.value = int16(v)
 	return err
 }
 
 func (i *int16Value) Get() interface{} {
-	return int16(*i)
+	return *i.value
 }
 
 func (i *int16Value) Type() string {
 	return "int16"
 }
 
-func (i *int16Value) String() string { return strconv.FormatInt(int64(*i), 10) }
+func (i *int16Value) String() string { return strconv.FormatInt(int64(*i.value), 10) }
 
 // GetInt16 returns the int16 value of a flag with the given name
 func (f *FlagSet) GetInt16(name string) (int16, error) {
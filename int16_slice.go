@@ -5,12 +5,13 @@ package zflag
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 )
 
 // -- int16Slice Value
 type int16SliceValue struct {
+	numberParser
+	sliceLimits
 	value   *[]int16
 	changed bool
 }
@@ -31,13 +32,10 @@ func (s *int16SliceValue) Set(val string) error {
 	out := make([]int16, len(ss))
 	for i, d := range ss {
 		var err error
-		var temp64 int64
-		temp64, err = strconv.ParseInt(d, 0, 16)
+		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
-		out[i] = int16(temp64)
-
 	}
 	if !s.changed {
 		*s.value = out
@@ -61,7 +59,7 @@ func (s *int16SliceValue) String() string {
 }
 
 func (s *int16SliceValue) fromString(val string) (int16, error) {
-	t64, err := strconv.ParseInt(val, 0, 16)
+	t64, err := s.parseInt(val, 16)
 	if err != nil {
 		return 0, err
 	}
@@ -73,6 +71,14 @@ func (s *int16SliceValue) toString(val int16) string {
 }
 
 func (s *int16SliceValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *int16SliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *int16SliceValue) appendValue(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
 		return err
@@ -81,13 +87,13 @@ func (s *int16SliceValue) Append(val string) error {
 	return nil
 }
 
-func (s *int16SliceValue) Replace(val []string) error {
+func (s *int16SliceValue) replaceValues(val []string) error {
 	out := make([]int16, len(val))
 	for i, d := range val {
 		var err error
 		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
 	}
 	*s.value = out
@@ -102,6 +108,11 @@ func (s *int16SliceValue) GetSlice() []string {
 	return out
 }
 
+func (s *int16SliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetInt16Slice return the []int16 value of a flag with the given name
 func (f *FlagSet) GetInt16Slice(name string) ([]int16, error) {
 	val, err := f.getFlagType(name, "int16Slice")
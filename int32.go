@@ -6,28 +6,31 @@ package zflag
 import "strconv"
 
 // -- int32 Value
-type int32Value int32
+type int32Value struct {
+	numberParser
+	value *int32
+}
 
 func newInt32Value(val int32, p *int32) *int32Value {
 	*p = val
-	return (*int32Value)(p)
+	return &int32Value{value: p}
 }
 
 func (i *int32Value) Set(s string) error {
-	v, err := strconv.ParseInt(s, 0, 32)
-	*i = int32Value(v)
+	v, err := i.parseInt(s, 32)
+	*i.value = int32(v)
 	return err
 }
 
 func (i *int32Value) Get() interface{} {
-	return int32(*i)
+	return *i.value
 }
 
 func (i *int32Value) Type() string {
 	return "int32"
 }
 
-func (i *int32Value) String() string { return strconv.FormatInt(int64(*i), 10) }
+func (i *int32Value) String() string { return strconv.FormatInt(int64(*i.value), 10) }
 
 // GetInt32 return the int32 value of a flag with the given name
 func (f *FlagSet) GetInt32(name string) (int32, error) {
@@ -5,12 +5,13 @@ package zflag
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 )
 
 // -- int32Slice Value
 type int32SliceValue struct {
+	numberParser
+	sliceLimits
 	value   *[]int32
 	changed bool
 }
@@ -27,13 +28,10 @@ func (s *int32SliceValue) Set(val string) error {
 	out := make([]int32, len(ss))
 	for i, d := range ss {
 		var err error
-		var temp64 int64
-		temp64, err = strconv.ParseInt(d, 0, 32)
+		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
-		out[i] = int32(temp64)
-
 	}
 	if !s.changed {
 		*s.value = out
@@ -61,7 +59,7 @@ func (s *int32SliceValue) String() string {
 }
 
 func (s *int32SliceValue) fromString(val string) (int32, error) {
-	t64, err := strconv.ParseInt(val, 0, 32)
+	t64, err := s.parseInt(val, 32)
 	if err != nil {
 		return 0, err
 	}
@@ -73,6 +71,14 @@ func (s *int32SliceValue) toString(val int32) string {
 }
 
 func (s *int32SliceValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *int32SliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *int32SliceValue) appendValue(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
 		return err
@@ -81,13 +87,13 @@ func (s *int32SliceValue) Append(val string) error {
 	return nil
 }
 
-func (s *int32SliceValue) Replace(val []string) error {
+func (s *int32SliceValue) replaceValues(val []string) error {
 	out := make([]int32, len(val))
 	for i, d := range val {
 		var err error
 		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
 	}
 	*s.value = out
@@ -102,6 +108,11 @@ func (s *int32SliceValue) GetSlice() []string {
 	return out
 }
 
+func (s *int32SliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetInt32Slice return the []int32 value of a flag with the given name
 func (f *FlagSet) GetInt32Slice(name string) ([]int32, error) {
 	val, err := f.getFlagType(name, "int32Slice")
@@ -6,28 +6,31 @@ package zflag
 import "strconv"
 
 // -- int64 Value
-type int64Value int64
+type int64Value struct {
+	numberParser
+	value *int64
+}
 
 func newInt64Value(val int64, p *int64) *int64Value {
 	*p = val
-	return (*int64Value)(p)
+	return &int64Value{value: p}
 }
 
 func (i *int64Value) Set(s string) error {
-	v, err := strconv.ParseInt(s, 0, 64)
-	*i = int64Value(v)
+	v, err := i.parseInt(s, 64)
+	*i.value = int64(v)
 	return err
 }
 
 func (i *int64Value) Get() interface{} {
-	return int64(*i)
+	return *i.value
 }
 
 func (i *int64Value) Type() string {
 	return "int64"
 }
 
-func (i *int64Value) String() string { return strconv.FormatInt(int64(*i), 10) }
+func (i *int64Value) String() string { return strconv.FormatInt(*i.value, 10) }
 
 // GetInt64 return the int64 value of a flag with the given name
 func (f *FlagSet) GetInt64(name string) (int64, error) {
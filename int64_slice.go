@@ -5,12 +5,13 @@ package zflag
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 )
 
 // -- int64Slice Value
 type int64SliceValue struct {
+	numberParser
+	sliceLimits
 	value   *[]int64
 	changed bool
 }
@@ -27,11 +28,10 @@ func (s *int64SliceValue) Set(val string) error {
 	out := make([]int64, len(ss))
 	for i, d := range ss {
 		var err error
-		out[i], err = strconv.ParseInt(d, 0, 64)
+		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
-
 	}
 	if !s.changed {
 		*s.value = out
@@ -59,7 +59,7 @@ func (s *int64SliceValue) String() string {
 }
 
 func (s *int64SliceValue) fromString(val string) (int64, error) {
-	return strconv.ParseInt(val, 0, 64)
+	return s.parseInt(val, 64)
 }
 
 func (s *int64SliceValue) toString(val int64) string {
@@ -67,6 +67,14 @@ func (s *int64SliceValue) toString(val int64) string {
 }
 
 func (s *int64SliceValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *int64SliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *int64SliceValue) appendValue(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
 		return err
@@ -75,13 +83,13 @@ func (s *int64SliceValue) Append(val string) error {
 	return nil
 }
 
-func (s *int64SliceValue) Replace(val []string) error {
+func (s *int64SliceValue) replaceValues(val []string) error {
 	out := make([]int64, len(val))
 	for i, d := range val {
 		var err error
 		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
 	}
 	*s.value = out
@@ -96,6 +104,11 @@ func (s *int64SliceValue) GetSlice() []string {
 	return out
 }
 
+func (s *int64SliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetInt64Slice return the []int64 value of a flag with the given name
 func (f *FlagSet) GetInt64Slice(name string) ([]int64, error) {
 	val, err := f.getFlagType(name, "int64Slice")
@@ -6,28 +6,31 @@ package zflag
 import "strconv"
 
 // -- int8 Value
-type int8Value int8
+type int8Value struct {
+	numberParser
+	value *int8
+}
 
 func newInt8Value(val int8, p *int8) *int8Value {
 	*p = val
-	return (*int8Value)(p)
+	return &int8Value{value: p}
 }
 
 func (i *int8Value) Set(s string) error {
-	v, err := strconv.ParseInt(s, 0, 8)
-	*i = int8Value(v)
+	v, err := i.parseInt(s, 8)
+	*i.value = int8(v)
 	return err
 }
 
 func (i *int8Value) Get() interface{} {
-	return int8(*i)
+	return *i.value
 }
 
 func (i *int8Value) Type() string {
 	return "int8"
 }
 
-func (i *int8Value) String() string { return strconv.FormatInt(int64(*i), 10) }
+func (i *int8Value) String() string { return strconv.FormatInt(int64(*i.value), 10) }
 
 // GetInt8 return the int8 value of a flag with the given name
 func (f *FlagSet) GetInt8(name string) (int8, error) {
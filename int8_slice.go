@@ -5,12 +5,13 @@ package zflag
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 )
 
 // -- int8Slice Value
 type int8SliceValue struct {
+	numberParser
+	sliceLimits
 	value   *[]int8
 	changed bool
 }
@@ -31,13 +32,10 @@ func (s *int8SliceValue) Set(val string) error {
 	out := make([]int8, len(ss))
 	for i, d := range ss {
 		var err error
-		var temp64 int64
-		temp64, err = strconv.ParseInt(d, 0, 8)
+		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
-		out[i] = int8(temp64)
-
 	}
 	if !s.changed {
 		*s.value = out
@@ -61,7 +59,7 @@ func (s *int8SliceValue) String() string {
 }
 
 func (s *int8SliceValue) fromString(val string) (int8, error) {
-	t64, err := strconv.ParseInt(val, 0, 8)
+	t64, err := s.parseInt(val, 8)
 	if err != nil {
 		return 0, err
 	}
@@ -73,6 +71,14 @@ func (s *int8SliceValue) toString(val int8) string {
 }
 
 func (s *int8SliceValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *int8SliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *int8SliceValue) appendValue(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
 		return err
@@ -81,13 +87,13 @@ func (s *int8SliceValue) Append(val string) error {
 	return nil
 }
 
-func (s *int8SliceValue) Replace(val []string) error {
+func (s *int8SliceValue) replaceValues(val []string) error {
 	out := make([]int8, len(val))
 	for i, d := range val {
 		var err error
 		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
 	}
 	*s.value = out
@@ -102,6 +108,11 @@ func (s *int8SliceValue) GetSlice() []string {
 	return out
 }
 
+func (s *int8SliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetInt8Slice return the []int8 value of a flag with the given name
 func (f *FlagSet) GetInt8Slice(name string) ([]int8, error) {
 	val, err := f.getFlagType(name, "int8Slice")
@@ -11,12 +11,15 @@ import (
 
 // -- intSlice Value
 type intSliceValue struct {
+	numberParser
+	sliceLimits
 	value   *[]int
 	changed bool
 }
 
 func newIntSliceValue(val []int, p *[]int) *intSliceValue {
 	isv := new(intSliceValue)
+	isv.decimalZero = true
 	isv.value = p
 	*isv.value = val
 	return isv
@@ -27,11 +30,10 @@ func (s *intSliceValue) Set(val string) error {
 	out := make([]int, len(ss))
 	for i, d := range ss {
 		var err error
-		out[i], err = strconv.Atoi(d)
+		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
-
 	}
 	if !s.changed {
 		*s.value = out
@@ -58,8 +60,24 @@ func (s *intSliceValue) String() string {
 	return "[" + strings.Join(out, ",") + "]"
 }
 
+func (s *intSliceValue) fromString(val string) (int, error) {
+	t64, err := s.parseInt(val, 0)
+	if err != nil {
+		return 0, err
+	}
+	return int(t64), nil
+}
+
 func (s *intSliceValue) Append(val string) error {
-	i, err := strconv.Atoi(val)
+	return s.limitAppend(s, val)
+}
+
+func (s *intSliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *intSliceValue) appendValue(val string) error {
+	i, err := s.fromString(val)
 	if err != nil {
 		return err
 	}
@@ -67,13 +85,13 @@ func (s *intSliceValue) Append(val string) error {
 	return nil
 }
 
-func (s *intSliceValue) Replace(val []string) error {
+func (s *intSliceValue) replaceValues(val []string) error {
 	out := make([]int, len(val))
 	for i, d := range val {
 		var err error
-		out[i], err = strconv.Atoi(d)
+		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
 	}
 	*s.value = out
@@ -88,6 +106,11 @@ func (s *intSliceValue) GetSlice() []string {
 	return out
 }
 
+func (s *intSliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetIntSlice return the []int value of a flag with the given name
 func (f *FlagSet) GetIntSlice(name string) ([]int, error) {
 	val, err := f.getFlagType(name, "intSlice")
@@ -12,6 +12,7 @@ import (
 
 // -- ipSlice Value
 type ipSliceValue struct {
+	sliceLimits
 	value   *[]net.IP
 	changed bool
 }
@@ -88,6 +89,14 @@ func (s *ipSliceValue) toString(val net.IP) string {
 }
 
 func (s *ipSliceValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *ipSliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *ipSliceValue) appendValue(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
 		return err
@@ -96,7 +105,7 @@ func (s *ipSliceValue) Append(val string) error {
 	return nil
 }
 
-func (s *ipSliceValue) Replace(val []string) error {
+func (s *ipSliceValue) replaceValues(val []string) error {
 	out := make([]net.IP, len(val))
 	for i, d := range val {
 		var err error
@@ -117,6 +126,11 @@ func (s *ipSliceValue) GetSlice() []string {
 	return out
 }
 
+func (s *ipSliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetIPSlice returns the []net.IP value of a flag with the given name
 func (f *FlagSet) GetIPSlice(name string) ([]net.IP, error) {
 	val, err := f.getFlagType(name, "ipSlice")
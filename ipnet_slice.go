@@ -12,6 +12,7 @@ import (
 
 // -- ipNetSlice Value
 type ipNetSliceValue struct {
+	sliceLimits
 	value   *[]net.IPNet
 	changed bool
 }
@@ -43,11 +44,11 @@ func (s *ipNetSliceValue) Set(val string) error {
 	// parse ip values into slice
 	out := make([]net.IPNet, 0, len(ipNetStrSlice))
 	for _, ipNetStr := range ipNetStrSlice {
-		_, n, err := net.ParseCIDR(strings.TrimSpace(ipNetStr))
+		n, err := s.fromString(ipNetStr)
 		if err != nil {
-			return fmt.Errorf("invalid string being converted to CIDR: %s", ipNetStr)
+			return err
 		}
-		out = append(out, *n)
+		out = append(out, n)
 	}
 
 	if !s.changed {
@@ -78,6 +79,61 @@ func (s *ipNetSliceValue) String() string {
 	return "[" + out + "]"
 }
 
+func (s *ipNetSliceValue) fromString(val string) (net.IPNet, error) {
+	_, n, err := net.ParseCIDR(strings.TrimSpace(val))
+	if err != nil {
+		return net.IPNet{}, fmt.Errorf("invalid string being converted to CIDR: %s", val)
+	}
+	return *n, nil
+}
+
+func (s *ipNetSliceValue) toString(val net.IPNet) string {
+	return val.String()
+}
+
+func (s *ipNetSliceValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *ipNetSliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *ipNetSliceValue) appendValue(val string) error {
+	n, err := s.fromString(val)
+	if err != nil {
+		return err
+	}
+	*s.value = append(*s.value, n)
+	return nil
+}
+
+func (s *ipNetSliceValue) replaceValues(val []string) error {
+	out := make([]net.IPNet, len(val))
+	for i, d := range val {
+		var err error
+		out[i], err = s.fromString(d)
+		if err != nil {
+			return err
+		}
+	}
+	*s.value = out
+	return nil
+}
+
+func (s *ipNetSliceValue) GetSlice() []string {
+	out := make([]string, len(*s.value))
+	for i, d := range *s.value {
+		out[i] = s.toString(d)
+	}
+	return out
+}
+
+func (s *ipNetSliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetIPNetSlice returns the []net.IPNet value of a flag with the given name
 func (f *FlagSet) GetIPNetSlice(name string) ([]net.IPNet, error) {
 	val, err := f.getFlagType(name, "ipNetSlice")
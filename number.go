@@ -0,0 +1,144 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NumberSyntax selects the syntax that numeric flags accept beyond plain
+// decimal numbers, see OptNumberSyntax. The same syntax applies to the int,
+// uint and float flags and to each element of their slices.
+type NumberSyntax uint
+
+const (
+	// NumberHex accepts the prefix 0x for hexadecimal integers and floats.
+	NumberHex NumberSyntax = 1 << iota
+	// NumberOctal accepts the prefix 0o and a leading 0 for octal integers.
+	// IntSlice and UintSlice flags only take a leading 0 as octal if it is
+	// set with OptNumberSyntax.
+	NumberOctal
+	// NumberBinary accepts the prefix 0b for binary integers.
+	NumberBinary
+	// NumberUnderscores accepts underscores between digits, e.g. 1_000_000.
+	NumberUnderscores
+	// NumberTrimSpace ignores leading and trailing whitespace.
+	NumberTrimSpace
+
+	// NumberStrict accepts plain decimal numbers only.
+	NumberStrict NumberSyntax = 0
+	// NumberDefault is the syntax of numeric flags without OptNumberSyntax,
+	// the syntax of Go number literals with surrounding whitespace ignored.
+	NumberDefault = NumberHex | NumberOctal | NumberBinary | NumberUnderscores | NumberTrimSpace
+)
+
+// numberParser parses the values of numeric flags with their NumberSyntax,
+// NumberDefault unless it was set.
+type numberParser struct {
+	syntax    NumberSyntax
+	syntaxSet bool
+	// decimalZero keeps a leading 0 decimal unless the syntax was set, for
+	// the IntSlice and UintSlice flags that always parsed it that way.
+	decimalZero bool
+}
+
+// numberValue is implemented by the values of numeric flags.
+type numberValue interface {
+	setNumberSyntax(NumberSyntax)
+}
+
+func (p *numberParser) setNumberSyntax(syntax NumberSyntax) {
+	p.syntax = syntax
+	p.syntaxSet = true
+}
+
+func (p *numberParser) numberSyntax() NumberSyntax {
+	if !p.syntaxSet {
+		return NumberDefault
+	}
+	return p.syntax
+}
+
+// prepare checks s against the syntax and returns it ready for strconv with
+// base 0, which accepts all prefixes and underscores.
+func (p *numberParser) prepare(fn, s string, integer bool) (string, error) {
+	syntax := p.numberSyntax()
+	if syntax&NumberTrimSpace != 0 {
+		s = strings.TrimSpace(s)
+	}
+	syntaxError := &strconv.NumError{Func: fn, Num: s, Err: strconv.ErrSyntax}
+
+	if syntax&NumberUnderscores == 0 && strings.Contains(s, "_") {
+		return "", syntaxError
+	}
+
+	sign, digits := "", s
+	if len(digits) > 0 && (digits[0] == '+' || digits[0] == '-') {
+		sign, digits = digits[:1], digits[1:]
+	}
+	if len(digits) < 2 || digits[0] != '0' {
+		return s, nil
+	}
+
+	var allowed NumberSyntax
+	switch digits[1] {
+	case 'x', 'X':
+		allowed = NumberHex
+	case 'o', 'O':
+		allowed = NumberOctal
+	case 'b', 'B':
+		allowed = NumberBinary
+	default:
+		octal := syntax&NumberOctal != 0 && (p.syntaxSet || !p.decimalZero)
+		if !integer || octal {
+			return s, nil
+		}
+		// Without NumberOctal a leading 0 does not change the base, the
+		// zeros are only stripped from decimal digits so that no prefix
+		// follows them, e.g. in 00x1f.
+		if strings.Trim(digits, "0123456789_") != "" {
+			return "", syntaxError
+		}
+		digits = strings.TrimLeft(digits, "0_")
+		if digits == "" {
+			digits = "0"
+		}
+		return sign + digits, nil
+	}
+	if syntax&allowed == 0 || (!integer && allowed != NumberHex) {
+		return "", syntaxError
+	}
+	return s, nil
+}
+
+func (p *numberParser) parseInt(s string, bitSize int) (int64, error) {
+	s, err := p.prepare("ParseInt", s, true)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(s, 0, bitSize)
+}
+
+func (p *numberParser) parseUint(s string, bitSize int) (uint64, error) {
+	s, err := p.prepare("ParseUint", s, true)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(s, 0, bitSize)
+}
+
+func (p *numberParser) parseFloat(s string, bitSize int) (float64, error) {
+	s, err := p.prepare("ParseFloat", s, false)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(s, bitSize)
+}
+
+// indexError names the index of the element of a slice that failed to parse.
+func indexError(i int, err error) error {
+	return fmt.Errorf("index %d: %v", i, err)
+}
@@ -0,0 +1,141 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNumberSyntaxDefault(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	n := f.Int("n", 0, "n")
+	u := f.Uint8("u", 0, "u")
+	x := f.Float64("x", 0, "x")
+	ints := f.IntSlice("ints", nil, "ints")
+	uints := f.UintSlice("uints", nil, "uints")
+	floats := f.Float32Slice("floats", nil, "floats")
+	err := f.Parse([]string{
+		"--n= 0x10 ", "--u=0b11", "--x=0x1p4",
+		"--ints=0x10,0o17,017,0b101,1_000, 5 ,-7",
+		"--uints=0x10,010",
+		"--floats=1_000.5,0x1p-2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *n != 16 || *u != 3 || *x != 16 {
+		t.Errorf("unexpected scalar values %d, %d, %v", *n, *u, *x)
+	}
+	if expected := []int{16, 15, 17, 5, 1000, 5, -7}; !reflect.DeepEqual(*ints, expected) {
+		t.Errorf("expected %v; got %v", expected, *ints)
+	}
+	if expected := []uint{16, 10}; !reflect.DeepEqual(*uints, expected) {
+		t.Errorf("expected %v; got %v", expected, *uints)
+	}
+	if expected := []float32{1000.5, 0.25}; !reflect.DeepEqual(*floats, expected) {
+		t.Errorf("expected %v; got %v", expected, *floats)
+	}
+}
+
+func TestNumberSyntaxLeadingZero(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	ports := f.IntSlice("p", nil, "p")
+	uints := f.UintSlice("u", nil, "u")
+	octal := f.IntSlice("o", nil, "o", OptNumberSyntax(NumberDefault))
+	int8s := f.Int8Slice("i8", nil, "i8")
+	n := f.Int("n", 0, "n")
+	err := f.Parse([]string{"--p=08,010", "--u=010", "--o=010", "--i8=010", "--n=010"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []int{8, 10}; !reflect.DeepEqual(*ports, expected) {
+		t.Errorf("expected %v; got %v", expected, *ports)
+	}
+	if expected := []uint{10}; !reflect.DeepEqual(*uints, expected) {
+		t.Errorf("expected %v; got %v", expected, *uints)
+	}
+	if expected := []int{8}; !reflect.DeepEqual(*octal, expected) {
+		t.Errorf("expected %v with NumberOctal; got %v", expected, *octal)
+	}
+	if expected := []int8{8}; !reflect.DeepEqual(*int8s, expected) {
+		t.Errorf("expected %v; got %v", expected, *int8s)
+	}
+	if *n != 8 {
+		t.Errorf("expected 8; got %d", *n)
+	}
+}
+
+func TestNumberSyntaxZeroPrefix(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.Int("a", 0, "a", OptNumberSyntax(NumberStrict))
+	f.Uint("u", 0, "u", OptNumberSyntax(NumberStrict))
+	f.Int("c", 0, "c", OptNumberSyntax(NumberHex|NumberUnderscores))
+	f.IntSlice("b", nil, "b")
+	f.UintSlice("d", nil, "d")
+	f.SetErrorPresentation(ErrorSilent)
+
+	for _, arg := range []string{"--a=00x1f", "--a=-00x1f", "--u=00b11", "--c=0_x10", "--c=00x10", "--b=00x10", "--b=0_x10", "--b=00b11", "--d=00o17"} {
+		if err := f.Parse([]string{arg}); err == nil {
+			t.Errorf("%s: expected an invalid syntax error", arg)
+		}
+	}
+}
+
+func TestNumberSyntaxStrict(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	n := f.Int("n", 0, "n", OptNumberSyntax(NumberStrict))
+	hex := f.Int64("hex", 0, "hex", OptNumberSyntax(NumberHex))
+	ints := f.Int16Slice("ints", nil, "ints", OptNumberSyntax(NumberStrict))
+	f.Float64("x", 0, "x", OptNumberSyntax(NumberStrict))
+	f.SetErrorPresentation(ErrorSilent)
+
+	if err := f.Parse([]string{"--n=010", "--hex=0xff", "--ints=-010,20"}); err != nil {
+		t.Fatal(err)
+	}
+	if *n != 10 || *hex != 255 {
+		t.Errorf("unexpected values %d, %d", *n, *hex)
+	}
+	if expected := []int16{-10, 20}; !reflect.DeepEqual(*ints, expected) {
+		t.Errorf("expected %v; got %v", expected, *ints)
+	}
+
+	tests := []struct {
+		arg      string
+		expected string
+	}{
+		{"--n=0x10", `invalid argument "0x10" for "--n" flag: strconv.ParseInt: parsing "0x10": invalid syntax`},
+		{"--n=1_000", `invalid argument "1_000" for "--n" flag: strconv.ParseInt: parsing "1_000": invalid syntax`},
+		{"--n= 5", `invalid argument " 5" for "--n" flag: strconv.ParseInt: parsing " 5": invalid syntax`},
+		{"--hex=0b1", `invalid argument "0b1" for "--hex" flag: strconv.ParseInt: parsing "0b1": invalid syntax`},
+		{"--x=0x1p4", `invalid argument "0x1p4" for "--x" flag: strconv.ParseFloat: parsing "0x1p4": invalid syntax`},
+		{"--ints=1,0x2", `invalid argument "1,0x2" for "--ints" flag: index 1: strconv.ParseInt: parsing "0x2": invalid syntax`},
+		{"--ints=1,2,40000", `invalid argument "1,2,40000" for "--ints" flag: index 2: strconv.ParseInt: parsing "40000": value out of range`},
+	}
+	for _, test := range tests {
+		err := f.Parse([]string{test.arg})
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("%s: expected %q; got %v", test.arg, test.expected, err)
+		}
+	}
+
+	v := f.Lookup("ints").Value.(SliceValue)
+	if err := v.Replace([]string{"1", "0b1"}); err == nil || err.Error() != `index 1: strconv.ParseInt: parsing "0b1": invalid syntax` {
+		t.Errorf("expected Replace to use the syntax of the flag; got %v", err)
+	}
+	if err := v.Append("0o1"); err == nil {
+		t.Error("expected Append to use the syntax of the flag")
+	}
+}
+
+func TestNumberSyntaxNotNumeric(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a flag that is not numeric")
+		}
+	}()
+	f := NewFlagSet("test", ContinueOnError)
+	f.String("s", "", "s", OptNumberSyntax(NumberStrict))
+}
@@ -0,0 +1,223 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Preset is a named set of flag values, applied when it is selected with a
+// presets flag, see PresetsVar.
+type Preset struct {
+	// Name selects the preset, e.g. --profile=ci.
+	Name string
+	// Description is shown in the usage of the presets flag.
+	Description string
+	// Values maps flag names to the values the preset sets.
+	Values map[string]string
+}
+
+// -- presets Value
+type presetsValue struct {
+	presets []Preset
+	value   *[]string
+	applied map[string]bool
+}
+
+func newPresetsValue(presets []Preset, p *[]string) *presetsValue {
+	*p = []string{}
+	return &presetsValue{presets: presets, value: p}
+}
+
+func (s *presetsValue) lookup(name string) *Preset {
+	for i := range s.presets {
+		if s.presets[i].Name == name {
+			return &s.presets[i]
+		}
+	}
+	return nil
+}
+
+// Format: ci,fast
+func (s *presetsValue) Set(val string) error {
+	names, err := readAsCSV(val)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	for _, name := range names {
+		if s.lookup(name) == nil {
+			available := make([]string, len(s.presets))
+			for i, preset := range s.presets {
+				available[i] = preset.Name
+			}
+			return fmt.Errorf("unknown preset %q, expected one of %v", name, available)
+		}
+	}
+	*s.value = append(*s.value, names...)
+	return nil
+}
+
+func (s *presetsValue) Get() interface{} {
+	return *s.value
+}
+
+func (s *presetsValue) Type() string {
+	return "preset"
+}
+
+func (s *presetsValue) String() string {
+	return strings.Join(*s.value, ",")
+}
+
+// presetsUsage appends the available presets to the usage string.
+func presetsUsage(presets []Preset, usage string) string {
+	if len(presets) == 0 {
+		return usage
+	}
+	usage += "\nPresets:"
+	for _, preset := range presets {
+		usage += "\n  " + preset.Name
+		if preset.Description != "" {
+			usage += ": " + preset.Description
+		}
+	}
+	return usage
+}
+
+// presetsLongUsage lists the values set by each preset.
+func presetsLongUsage(presets []Preset) string {
+	var lines []string
+	for _, preset := range presets {
+		names := make([]string, 0, len(preset.Values))
+		for name := range preset.Values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		line := preset.Name + ":"
+		for _, name := range names {
+			line += fmt.Sprintf(" --%s=%s", name, preset.Values[name])
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// GetPresets return the names of the presets selected with the presets flag
+// with the given name
+func (f *FlagSet) GetPresets(name string) ([]string, error) {
+	val, err := f.getFlagType(name, "preset")
+	if err != nil {
+		return []string{}, err
+	}
+	return val.([]string), nil
+}
+
+// MustGetPresets is like GetPresets, but panics on error.
+func (f *FlagSet) MustGetPresets(name string) []string {
+	val, err := f.GetPresets(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// LookupPreset returns the preset with the given name of the presets flag
+// with the given flag name, or nil.
+func (f *FlagSet) LookupPreset(flagName, name string) *Preset {
+	flag := f.Lookup(flagName)
+	if flag == nil {
+		return nil
+	}
+	if v, ok := flag.Value.(*presetsValue); ok {
+		return v.lookup(name)
+	}
+	return nil
+}
+
+// ApplyPresets applies the values of the selected presets with SourcePreset,
+// in the order the presets were selected. Flags set from a source of higher
+// priority, such as the command line, keep their value. Each preset is applied
+// once, so slice flags do not get its values twice. It is called by Parse
+// after the command line was parsed, and can be called again after selecting
+// presets from other sources.
+func (f *FlagSet) ApplyPresets() error {
+	var err error
+	f.VisitAll(func(flag *Flag) {
+		v, ok := flag.Value.(*presetsValue)
+		if !ok || err != nil {
+			return
+		}
+		for _, name := range *v.value {
+			if v.applied[name] {
+				continue
+			}
+			if v.applied == nil {
+				v.applied = make(map[string]bool)
+			}
+			v.applied[name] = true
+
+			preset := v.lookup(name)
+			names := make([]string, 0, len(preset.Values))
+			for name := range preset.Values {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				if err = f.SetWithSource(name, preset.Values[name], SourcePreset); err != nil {
+					err = fmt.Errorf("preset %q of flag --%s: %w", preset.Name, flag.Name, err)
+					return
+				}
+			}
+		}
+	})
+	return err
+}
+
+// applyPresets is ApplyPresets for parsing, presenting errors as configured.
+func (f *FlagSet) applyPresets() error {
+	if err := f.ApplyPresets(); err != nil {
+		return f.fail(&ParseError{Category: valueErrorCategory(err), Err: err})
+	}
+	return nil
+}
+
+// PresetsVar defines a presets flag with specified name, presets, and usage
+// string. Selecting a preset, e.g. --profile=ci, sets the values of the
+// preset after the command line was parsed, see ApplyPresets. The argument p
+// points to a []string variable in which to store the names of the selected
+// presets. The usage string is followed by the list of presets.
+func (f *FlagSet) PresetsVar(p *[]string, name string, presets []Preset, usage string, opts ...Opt) {
+	opts = append([]Opt{OptLongUsage(presetsLongUsage(presets))}, opts...)
+	f.Var(newPresetsValue(presets, p), name, presetsUsage(presets, usage), opts...)
+}
+
+// PresetsVar defines a presets flag with specified name, presets, and usage
+// string. Selecting a preset, e.g. --profile=ci, sets the values of the
+// preset after the command line was parsed, see ApplyPresets. The argument p
+// points to a []string variable in which to store the names of the selected
+// presets. The usage string is followed by the list of presets.
+func PresetsVar(p *[]string, name string, presets []Preset, usage string, opts ...Opt) {
+	CommandLine.PresetsVar(p, name, presets, usage, opts...)
+}
+
+// Presets defines a presets flag with specified name, presets, and usage
+// string. The return value is the address of a []string variable that stores
+// the names of the selected presets. See PresetsVar.
+func (f *FlagSet) Presets(name string, presets []Preset, usage string, opts ...Opt) *[]string {
+	p := []string{}
+	f.PresetsVar(&p, name, presets, usage, opts...)
+	return &p
+}
+
+// Presets defines a presets flag with specified name, presets, and usage
+// string. The return value is the address of a []string variable that stores
+// the names of the selected presets. See PresetsVar.
+func Presets(name string, presets []Preset, usage string, opts ...Opt) *[]string {
+	return CommandLine.Presets(name, presets, usage, opts...)
+}
@@ -0,0 +1,168 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func setUpPresetFlagSet() (*FlagSet, *[]string) {
+	fs := NewFlagSet("test", ContinueOnError)
+	profile := fs.Presets("profile", []Preset{
+		{Name: "ci", Description: "settings for CI", Values: map[string]string{"workers": "4", "requests": "1000"}},
+		{Name: "quick", Description: "a quick smoke test", Values: map[string]string{"requests": "10"}},
+	}, "apply a `profile` of settings")
+	fs.Int("workers", 1, "number of workers")
+	fs.Int("requests", 100, "number of requests")
+	fs.SetErrorPresentation(ErrorSilent)
+	return fs, profile
+}
+
+func TestPresets(t *testing.T) {
+	fs, profile := setUpPresetFlagSet()
+	if err := fs.Parse([]string{"--workers=2", "--profile=ci"}); err != nil {
+		t.Fatal(err)
+	}
+	if v := fs.MustGetInt("workers"); v != 2 {
+		t.Errorf("expected the command line to win; got %d", v)
+	}
+	if v := fs.MustGetInt("requests"); v != 1000 {
+		t.Errorf("expected the preset value; got %d", v)
+	}
+	if flag := fs.Lookup("requests"); !flag.Changed || flag.Source != SourcePreset {
+		t.Errorf("expected the flag to be changed by the preset; got %v", flag.Source)
+	}
+	if len(*profile) != 1 || (*profile)[0] != "ci" {
+		t.Errorf("unexpected selected presets %v", *profile)
+	}
+
+	fs, _ = setUpPresetFlagSet()
+	if err := fs.Parse([]string{"--profile=ci,quick"}); err != nil {
+		t.Fatal(err)
+	}
+	if v := fs.MustGetInt("requests"); v != 10 {
+		t.Errorf("expected the last preset to win; got %d", v)
+	}
+	if presets := fs.MustGetPresets("profile"); strings.Join(presets, ",") != "ci,quick" {
+		t.Errorf("unexpected selected presets %v", presets)
+	}
+}
+
+func TestPresetsHigherPrioritySources(t *testing.T) {
+	fs, _ := setUpPresetFlagSet()
+	if err := fs.SetWithSource("requests", "50", SourceEnv); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Parse([]string{"--profile", "ci"}); err != nil {
+		t.Fatal(err)
+	}
+	if v := fs.MustGetInt("requests"); v != 50 {
+		t.Errorf("expected the env value to win; got %d", v)
+	}
+
+	if err := fs.SetWithSource("profile", "quick", SourceConfig); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.ApplyPresets(); err != nil {
+		t.Fatal(err)
+	}
+	if v := fs.MustGetInt("workers"); v != 4 {
+		t.Errorf("expected the preset value to remain; got %d", v)
+	}
+}
+
+func TestPresetsErrors(t *testing.T) {
+	fs, _ := setUpPresetFlagSet()
+	err := fs.Parse([]string{"--profile=nightly"})
+	if err == nil || !strings.Contains(err.Error(), `unknown preset "nightly", expected one of [ci quick]`) {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	fs = NewFlagSet("test", ContinueOnError)
+	fs.Presets("profile", []Preset{{Name: "bad", Values: map[string]string{"missing": "1"}}}, "profile")
+	fs.SetErrorPresentation(ErrorSilent)
+	err = fs.Parse([]string{"--profile=bad"})
+	if err == nil || err.Error() != `preset "bad" of flag --profile: unknown flag: --missing` {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestPresetsUsage(t *testing.T) {
+	fs, _ := setUpPresetFlagSet()
+
+	expected := `      --profile profile   apply a profile of settings
+                          Presets:
+                            ci: settings for CI
+                            quick: a quick smoke test
+`
+	if usage := fs.FlagUsagesForGroup(""); !strings.HasPrefix(usage, expected) {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, usage)
+	}
+
+	if preset := fs.LookupPreset("profile", "quick"); preset == nil || preset.Values["requests"] != "10" {
+		t.Errorf("unexpected preset %v", preset)
+	}
+	if fs.LookupPreset("workers", "quick") != nil {
+		t.Error("expected no preset for a regular flag")
+	}
+
+	buf := new(bytes.Buffer)
+	fs.SetOutput(buf)
+	_ = fs.Parse([]string{"--help=profile"})
+	if !strings.Contains(buf.String(), "    ci: --requests=1000 --workers=4\n    quick: --requests=10\n") {
+		t.Errorf("expected the preset values in the flag help; got:\n%s", buf.String())
+	}
+}
+
+func TestPresetsAppliedOnce(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.Presets("profile", []Preset{
+		{Name: "ci", Values: map[string]string{"tags": "ci,slow"}},
+		{Name: "quick", Values: map[string]string{"tags": "quick"}},
+	}, "apply a `profile` of settings")
+	tags := fs.StringSlice("tags", nil, "tags")
+
+	if err := fs.Parse([]string{"--profile=ci", "--profile=ci"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.ApplyPresets(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(*tags, ",") != "ci,slow" {
+		t.Errorf("expected the preset to be applied once; got %v", *tags)
+	}
+
+	if err := fs.Set("profile", "quick"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.ApplyPresets(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(*tags, ",") != "ci,slow,quick" {
+		t.Errorf("expected the new preset to be applied; got %v", *tags)
+	}
+}
+
+func TestPresetsExperimental(t *testing.T) {
+	restoreEnv(t, DefaultExperimentalEnv)
+	os.Unsetenv(DefaultExperimentalEnv)
+
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.Presets("profile", []Preset{
+		{Name: "fast", Values: map[string]string{"turbo": "true"}},
+	}, "apply a `profile` of settings")
+	fs.Bool("turbo", false, "go faster", OptExperimental(StageAlpha))
+	fs.SetErrorPresentation(ErrorSilent)
+
+	err := fs.Parse([]string{"--profile=fast"})
+	if ErrorCategoryOf(err) != ErrorExperimentalFlag {
+		t.Errorf("expected an experimental flag error; got %v", err)
+	}
+	if fs.Lookup("turbo").Changed {
+		t.Error("expected the experimental flag not to be set")
+	}
+}
@@ -0,0 +1,231 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SliceOptions configure how the values of a slice flag are set, see
+// OptSeparator, OptNoSplit, OptAppendDefault, OptItems and OptClearToken.
+type SliceOptions struct {
+	// Separator splits values instead of the comma-separated default format.
+	Separator string
+	// NoSplit takes every value as one item, like StringArray flags.
+	NoSplit bool
+	// AppendDefault appends the first value to the default instead of
+	// replacing it.
+	AppendDefault bool
+	// MinItems is the minimum number of items if the flag is set, 0 for no
+	// minimum.
+	MinItems int
+	// MaxItems is the maximum number of items, 0 for no maximum.
+	MaxItems int
+	// ClearToken is a value that removes all items, e.g. "none".
+	ClearToken string
+}
+
+// sliceMerger is an optional interface for slice values to combine the
+// current items with new items themselves, e.g. to remove members of a set.
+type sliceMerger interface {
+	mergeItems(current, items []string, replace bool) ([]string, error)
+}
+
+// sliceOptionsOf returns the slice options of flag, creating them if needed.
+func sliceOptionsOf(flag *Flag) (*SliceOptions, error) {
+	if _, ok := flag.Value.(SliceValue); !ok {
+		return nil, fmt.Errorf("flag %q is not a slice flag", flag.Name)
+	}
+	if flag.SliceOptions == nil {
+		flag.SliceOptions = &SliceOptions{}
+	}
+	if l, ok := flag.Value.(sliceLimiter); ok {
+		l.setSliceOptions(flag.SliceOptions)
+	}
+	return flag.SliceOptions, nil
+}
+
+// sliceLimiter is implemented by the slice values of this package, which
+// apply the MaxItems and ClearToken options in their Append and Replace
+// methods too.
+type sliceLimiter interface {
+	setSliceOptions(o *SliceOptions)
+}
+
+// rawSlice is implemented by the slice values of this package to change their
+// items without the slice options.
+type rawSlice interface {
+	appendValue(val string) error
+	replaceValues(val []string) error
+	snapshot() func()
+	GetSlice() []string
+}
+
+// sliceLimits applies the slice options of a flag to the Append and Replace
+// methods of its value.
+type sliceLimits struct {
+	options *SliceOptions
+}
+
+func (l *sliceLimits) setSliceOptions(o *SliceOptions) {
+	l.options = o
+}
+
+// limitAppend appends val to s, clearing s for the clear token.
+func (l *sliceLimits) limitAppend(s rawSlice, val string) error {
+	o := l.options
+	if o == nil {
+		return s.appendValue(val)
+	}
+	if o.ClearToken != "" && val == o.ClearToken {
+		return s.replaceValues([]string{})
+	}
+	return o.limit(s, func() error { return s.appendValue(val) })
+}
+
+// limitReplace replaces the items of s with val, clearing s if val is only
+// the clear token.
+func (l *sliceLimits) limitReplace(s rawSlice, val []string) error {
+	o := l.options
+	if o == nil {
+		return s.replaceValues(val)
+	}
+	if o.ClearToken != "" && len(val) == 1 && val[0] == o.ClearToken {
+		return s.replaceValues([]string{})
+	}
+	return o.limit(s, func() error { return s.replaceValues(val) })
+}
+
+// limit changes s with change and restores it if it ends up with more than
+// MaxItems items.
+func (o *SliceOptions) limit(s rawSlice, change func() error) error {
+	if o.MaxItems == 0 {
+		return change()
+	}
+	restore := s.snapshot()
+	if err := change(); err != nil {
+		return err
+	}
+	if err := o.checkMax(len(s.GetSlice())); err != nil {
+		restore()
+		return err
+	}
+	return nil
+}
+
+// checkMax checks that n items are not more than MaxItems.
+func (o *SliceOptions) checkMax(n int) error {
+	if o.MaxItems > 0 && n > o.MaxItems {
+		return fmt.Errorf("too many items, expected at most %d, got %d", o.MaxItems, n)
+	}
+	return nil
+}
+
+// split returns the items of val for the value of flag.
+func (o *SliceOptions) split(flag *Flag, val string) ([]string, error) {
+	_, isArray := flag.Value.(*stringArrayValue)
+	switch {
+	case o.NoSplit:
+		return []string{val}, nil
+	case val == "":
+		return []string{}, nil
+	case o.Separator != "":
+		return strings.Split(val, o.Separator), nil
+	case isArray:
+		return []string{val}, nil
+	}
+	items, err := readAsCSV(val)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return items, nil
+}
+
+// set sets the value of a slice flag according to the options. Only the new
+// items are parsed, the current items are kept as they are.
+func (o *SliceOptions) set(flag *Flag, val string) error {
+	s := flag.Value.(SliceValue)
+	if o.ClearToken != "" && val == o.ClearToken {
+		return s.Replace([]string{})
+	}
+
+	items, err := o.split(flag, val)
+	if err != nil {
+		return err
+	}
+
+	replace := !flag.Changed && !o.AppendDefault
+	if m, ok := flag.Value.(sliceMerger); ok {
+		out, err := m.mergeItems(s.GetSlice(), items, replace)
+		if err != nil {
+			return err
+		}
+		if err := o.checkMax(len(out)); err != nil {
+			return err
+		}
+		return s.Replace(out)
+	}
+
+	n := len(items)
+	if !replace {
+		n += len(s.GetSlice())
+	}
+	if err := o.checkMax(n); err != nil {
+		return err
+	}
+
+	appendItem, replaceItems := s.Append, s.Replace
+	if r, ok := flag.Value.(rawSlice); ok {
+		appendItem, replaceItems = r.appendValue, r.replaceValues
+	}
+	if replace {
+		return replaceItems(items)
+	}
+	restore := snapshotValue(flag.Value)
+	for i, item := range items {
+		if err := appendItem(item); err != nil {
+			restore()
+			return indexError(i, err)
+		}
+	}
+	return nil
+}
+
+// constraint describes the item limits, or is empty.
+func (o *SliceOptions) constraint() string {
+	switch {
+	case o.MinItems > 0 && o.MaxItems > 0:
+		return fmt.Sprintf("%d-%d items", o.MinItems, o.MaxItems)
+	case o.MinItems > 0:
+		return "at least " + pluralItems(o.MinItems)
+	case o.MaxItems > 0:
+		return "at most " + pluralItems(o.MaxItems)
+	}
+	return ""
+}
+
+func pluralItems(n int) string {
+	if n == 1 {
+		return "1 item"
+	}
+	return fmt.Sprintf("%d items", n)
+}
+
+// checkSliceItems checks the minimum number of items of the slice flags that
+// were set or implied.
+func (f *FlagSet) checkSliceItems() error {
+	var err error
+	f.VisitAll(func(flag *Flag) {
+		o := flag.SliceOptions
+		if err != nil || o == nil || o.MinItems == 0 || !flag.isSet() {
+			return
+		}
+		if n := len(flag.Value.(SliceValue).GetSlice()); n < o.MinItems {
+			err = f.failf(ErrorInvalidValue, flag, "flag --%s needs at least %s, got %d", flag.Name, pluralItems(o.MinItems), n)
+		}
+	})
+	return err
+}
@@ -0,0 +1,197 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestSliceOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Opt
+		args     []string
+		expected []int
+	}{
+		{"default", nil, []string{"--ints=3,4", "--ints=5"}, []int{3, 4, 5}},
+		{"separator", []Opt{OptSeparator(":")}, []string{"--ints=3:4", "--ints=5"}, []int{3, 4, 5}},
+		{"no split", []Opt{OptNoSplit()}, []string{"--ints=3", "--ints=4"}, []int{3, 4}},
+		{"append default", []Opt{OptAppendDefault()}, []string{"--ints=3,4"}, []int{1, 2, 3, 4}},
+		{"clear", []Opt{OptClearToken("none")}, []string{"--ints=none"}, []int{}},
+		{"clear and append", []Opt{OptClearToken("none")}, []string{"--ints=3", "--ints=none", "--ints=5"}, []int{5}},
+		{"clear default", []Opt{OptClearToken("none"), OptAppendDefault()}, []string{"--ints=none", "--ints=5"}, []int{5}},
+		{"items", []Opt{OptItems(2, 3)}, []string{"--ints=3", "--ints=4,5"}, []int{3, 4, 5}},
+	}
+	for _, test := range tests {
+		var ints []int
+		f := NewFlagSet("test", ContinueOnError)
+		f.IntSliceVar(&ints, "ints", []int{1, 2}, "ints", test.opts...)
+		if err := f.Parse(test.args); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(ints, test.expected) {
+			t.Errorf("%s: expected %v; got %v", test.name, test.expected, ints)
+		}
+	}
+}
+
+func TestSliceOptionsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Opt
+		args     []string
+		expected string
+	}{
+		{"no split", []Opt{OptNoSplit()}, []string{"--ints=3,4"}, `invalid argument "3,4" for "--ints" flag: index 0: strconv.ParseInt: parsing "3,4": invalid syntax`},
+		{"max items", []Opt{OptItems(0, 2)}, []string{"--ints=3", "--ints=4,5"}, `invalid argument "4,5" for "--ints" flag: too many items, expected at most 2, got 3`},
+		{"append default max items", []Opt{OptItems(0, 2), OptAppendDefault()}, []string{"--ints=3"}, `invalid argument "3" for "--ints" flag: too many items, expected at most 2, got 3`},
+		{"index in value", []Opt{OptItems(0, 10)}, []string{"--ints=1,2", "--ints=x"}, `invalid argument "x" for "--ints" flag: index 0: strconv.ParseInt: parsing "x": invalid syntax`},
+		{"min items", []Opt{OptItems(2, 0)}, []string{"--ints=3"}, `flag --ints needs at least 2 items, got 1`},
+		{"min items cleared", []Opt{OptItems(1, 0), OptClearToken("-")}, []string{"--ints=-"}, `flag --ints needs at least 1 item, got 0`},
+	}
+	for _, test := range tests {
+		f := NewFlagSet("test", ContinueOnError)
+		f.IntSlice("ints", []int{1, 2}, "ints", test.opts...)
+		f.SetErrorPresentation(ErrorSilent)
+		err := f.Parse(test.args)
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("%s: expected %q; got %v", test.name, test.expected, err)
+		}
+		if ErrorCategoryOf(err) != ErrorInvalidValue {
+			t.Errorf("%s: expected an invalid value error; got %v", test.name, ErrorCategoryOf(err))
+		}
+	}
+
+	f := NewFlagSet("test", ContinueOnError)
+	f.IntSlice("ints", []int{1, 2, 3}, "ints", OptItems(4, 0))
+	if err := f.Parse(nil); err != nil {
+		t.Errorf("expected the default to not be checked; got %v", err)
+	}
+}
+
+func TestSliceOptionsStrings(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	ss := f.StringSlice("ss", nil, "ss", OptSeparator(";"))
+	sa := f.StringArray("sa", []string{"a"}, "sa", OptAppendDefault())
+	set := f.StringSet("set", []string{"pod", "svc"}, "set", OptSeparator(" "), OptAppendDefault())
+	if err := f.Parse([]string{"--ss=a,b;c", "--sa=b,c", "--set=deploy -svc", "--set=pod"}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"a,b", "c"}; !reflect.DeepEqual(*ss, expected) {
+		t.Errorf("expected %v; got %v", expected, *ss)
+	}
+	if expected := []string{"a", "b,c"}; !reflect.DeepEqual(*sa, expected) {
+		t.Errorf("expected %v; got %v", expected, *sa)
+	}
+	if expected := []string{"pod", "deploy"}; !reflect.DeepEqual(*set, expected) {
+		t.Errorf("expected %v; got %v", expected, *set)
+	}
+}
+
+func TestSliceOptionsIPNet(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	nets := f.IPNetSlice("nets", nil, "nets", OptNoSplit())
+	if err := f.Parse([]string{"--nets=10.0.0.0/8", "--nets=192.168.0.0/16"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(*nets) != 2 || (*nets)[1].String() != "192.168.0.0/16" {
+		t.Errorf("unexpected value %v", *nets)
+	}
+
+	v := f.Lookup("nets").Value.(SliceValue)
+	if err := v.Replace([]string{"127.0.0.0/8"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Append("::1/128"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Append("localhost"); err == nil {
+		t.Error("expected an error for an invalid CIDR")
+	}
+	expected := []string{"127.0.0.0/8", "::1/128"}
+	if !reflect.DeepEqual(v.GetSlice(), expected) {
+		t.Errorf("expected %v; got %v", expected, v.GetSlice())
+	}
+	if (*nets)[0].Contains(net.ParseIP("127.0.0.1")) == false {
+		t.Errorf("unexpected value %v", *nets)
+	}
+}
+
+func TestSliceOptionsKeepValues(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	floats := f.Float64Slice("f", nil, "f", OptSeparator(";"))
+	if err := f.Parse([]string{"--f=0.0000001;1e20", "--f=2"}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []float64{0.0000001, 1e20, 2}; !reflect.DeepEqual(*floats, expected) {
+		t.Errorf("expected %v; got %v", expected, *floats)
+	}
+
+	f = NewFlagSet("test", ContinueOnError)
+	ints := f.IntSlice("ints", nil, "ints", OptSeparator(";"))
+	f.SetErrorPresentation(ErrorSilent)
+	if err := f.Parse([]string{"--ints=1;2", "--ints=3;x"}); err == nil {
+		t.Fatal("expected an error for an invalid item")
+	}
+	if expected := []int{1, 2}; !reflect.DeepEqual(*ints, expected) {
+		t.Errorf("expected the failed value to not be kept, %v; got %v", expected, *ints)
+	}
+}
+
+func TestSliceOptionsAppendReplace(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	ints := f.IntSlice("ints", nil, "ints", OptItems(0, 2), OptClearToken("none"))
+	v := f.Lookup("ints").Value.(SliceValue)
+
+	if err := v.Replace([]string{"1", "2", "3"}); err == nil || err.Error() != "too many items, expected at most 2, got 3" {
+		t.Errorf("expected a too many items error; got %v", err)
+	}
+	if err := v.Replace([]string{"1", "2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Append("3"); err == nil {
+		t.Error("expected a too many items error")
+	}
+	if expected := []int{1, 2}; !reflect.DeepEqual(*ints, expected) {
+		t.Errorf("expected %v; got %v", expected, *ints)
+	}
+
+	if err := v.Append("none"); err != nil {
+		t.Fatal(err)
+	}
+	if len(*ints) != 0 {
+		t.Errorf("expected the clear token to clear the items; got %v", *ints)
+	}
+	if err := v.Replace([]string{"4"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Replace([]string{"none"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(*ints) != 0 {
+		t.Errorf("expected the clear token to clear the items; got %v", *ints)
+	}
+}
+
+func TestSliceOptionsUsage(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	f.IntSlice("ints", nil, "ints", OptItems(2, 3))
+	f.IntSlice("more", nil, "more", OptItems(1, 0))
+
+	expected := "      --ints ints   ints (2-3 items)\n" +
+		"      --more ints   more (at least 1 item)\n"
+	if usage := f.FlagUsages(); usage != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, usage)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a flag that is not a slice")
+		}
+	}()
+	f.Int("int", 0, "int", OptNoSplit())
+}
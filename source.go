@@ -0,0 +1,81 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import "fmt"
+
+// ValueSource identifies where the value of a flag was set from. Sources are
+// ordered by priority: a flag set from one source is not overridden by
+// SetWithSource from a source of lower priority. SourceImplied is the
+// exception, it ranks just above SourceDefault.
+type ValueSource int
+
+const (
+	// SourceDefault is the source of flags that were not set.
+	SourceDefault ValueSource = iota
+	// SourcePreset is the source of values applied from a preset, see
+	// PresetsVar.
+	SourcePreset
+	// SourceConfig is the source of values read from a config file.
+	SourceConfig
+	// SourceEnv is the source of values read from environment variables.
+	SourceEnv
+	// SourceCommandLine is the source of values given on the command line,
+	// and of values set with Set.
+	SourceCommandLine
+	// SourceImplied is the source of values implied by another flag, see
+	// OptImplies. Flags with an implied value are not marked as Changed.
+	SourceImplied
+)
+
+var valueSourceNames = []string{"default", "preset", "config", "env", "command line", "implied"}
+
+func (s ValueSource) String() string {
+	if s >= 0 && int(s) < len(valueSourceNames) {
+		return valueSourceNames[s]
+	}
+	return fmt.Sprintf("ValueSource(%d)", int(s))
+}
+
+// Provenance describes where the value of the flag came from, e.g.
+// "command line" or "implied by --production".
+func (f *Flag) Provenance() string {
+	if f.Source == SourceImplied && f.ImpliedBy != "" {
+		return "implied by --" + f.ImpliedBy
+	}
+	return f.Source.String()
+}
+
+// priority returns the rank of the source when a flag is set from several
+// sources.
+func (s ValueSource) priority() int {
+	switch s {
+	case SourceDefault:
+		return 0
+	case SourceImplied:
+		return 1
+	}
+	return int(s) + 1
+}
+
+// isSet reports whether the value of the flag was set, or implied by another
+// flag.
+func (f *Flag) isSet() bool {
+	return f.Changed || f.Source == SourceImplied
+}
+
+// SetWithSource sets the value of the named flag like Set, recording source
+// as the flag's Source. The value is not set if the flag was already set from
+// a source of higher priority, e.g. config files calling SetWithSource with
+// SourceConfig do not override the command line.
+func (f *FlagSet) SetWithSource(name, value string, source ValueSource) error {
+	flag := f.Lookup(name)
+	if flag == nil {
+		return NewUnknownFlagError(name)
+	}
+	if flag.Changed && flag.Source.priority() > source.priority() {
+		return nil
+	}
+	return f.set(flag, value, source)
+}
@@ -0,0 +1,49 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import "testing"
+
+func TestSetWithSource(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.Int("workers", 1, "number of workers")
+	fs.Int("requests", 10, "number of requests")
+
+	if err := fs.Parse([]string{"--workers=4"}); err != nil {
+		t.Fatal(err)
+	}
+	if flag := fs.Lookup("workers"); flag.Source != SourceCommandLine {
+		t.Errorf("expected source %v; got %v", SourceCommandLine, flag.Source)
+	}
+	if flag := fs.Lookup("requests"); flag.Source != SourceDefault {
+		t.Errorf("expected source %v; got %v", SourceDefault, flag.Source)
+	}
+
+	if err := fs.SetWithSource("workers", "8", SourceConfig); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.SetWithSource("requests", "20", SourceConfig); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.SetWithSource("requests", "30", SourceEnv); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.SetWithSource("requests", "40", SourcePreset); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := fs.MustGetInt("workers"); v != 4 {
+		t.Errorf("expected the command line to win; got %d", v)
+	}
+	if v := fs.MustGetInt("requests"); v != 30 {
+		t.Errorf("expected the env to win; got %d", v)
+	}
+	if flag := fs.Lookup("requests"); !flag.Changed || flag.Source != SourceEnv {
+		t.Errorf("expected the flag to be changed from %v; got %v", SourceEnv, flag.Source)
+	}
+
+	if err := fs.SetWithSource("unknown", "1", SourceEnv); err == nil {
+		t.Error("expected an error for unknown flags")
+	}
+}
@@ -5,6 +5,7 @@ package zflag
 
 // -- stringArray Value
 type stringArrayValue struct {
+	sliceLimits
 	value   *[]string
 	changed bool
 }
@@ -34,11 +35,19 @@ func (s *stringArrayValue) Set(val string) error {
 }
 
 func (s *stringArrayValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *stringArrayValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *stringArrayValue) appendValue(val string) error {
 	*s.value = append(*s.value, val)
 	return nil
 }
 
-func (s *stringArrayValue) Replace(val []string) error {
+func (s *stringArrayValue) replaceValues(val []string) error {
 	out := make([]string, len(val))
 	copy(out, val)
 	*s.value = out
@@ -51,6 +60,11 @@ func (s *stringArrayValue) GetSlice() []string {
 	return out
 }
 
+func (s *stringArrayValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 func (s *stringArrayValue) Type() string {
 	return "stringArray"
 }
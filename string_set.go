@@ -0,0 +1,264 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"sort"
+)
+
+// -- stringSet Value
+// Sets are only provided for strings, a - removal would be ambiguous with
+// negative numbers.
+type stringSetValue struct {
+	members enumChoices
+	sorted  bool
+	sliceLimits
+	value   *[]string
+	changed bool
+}
+
+func newStringSetValue(val []string, p *[]string) *stringSetValue {
+	s := &stringSetValue{value: p}
+	*s.value = s.add([]string{}, val...)
+	return s
+}
+
+func (s *stringSetValue) choiceSet() *enumChoices {
+	return &s.members
+}
+
+// restrict limits the set to the given members, checking its current value.
+func (s *stringSetValue) restrict(members []string) error {
+	s.members.choices = Choices(members...)
+	return s.canonicalize()
+}
+
+// canonicalize replaces the current members with their canonical values,
+// removing duplicates.
+func (s *stringSetValue) canonicalize() error {
+	v, err := s.canonicalSlice(*s.value)
+	if err != nil {
+		return err
+	}
+	*s.value = s.add([]string{}, v...)
+	return nil
+}
+
+// canonical returns the member matching val, if the set is restricted to
+// known members. Without known members, OptCaseInsensitive only affects how
+// duplicates are detected, the first spelling of a member is kept.
+func (s *stringSetValue) canonical(val string) (string, error) {
+	if len(s.members.choices) == 0 {
+		return val, nil
+	}
+	return s.members.canonical(val)
+}
+
+func (s *stringSetValue) canonicalSlice(val []string) ([]string, error) {
+	out := make([]string, len(val))
+	for i, d := range val {
+		var err error
+		if out[i], err = s.canonical(d); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// add returns set with the values added that it does not contain yet.
+func (s *stringSetValue) add(set []string, values ...string) []string {
+	for _, val := range values {
+		if s.indexOf(set, val) < 0 {
+			set = append(set, val)
+		}
+	}
+	if s.sorted {
+		sort.Strings(set)
+	}
+	return set
+}
+
+// indexOf returns the index of val in set, or -1.
+func (s *stringSetValue) indexOf(set []string, val string) int {
+	for i, d := range set {
+		if s.members.equal(d, val) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isRemoval reports whether val removes a member, e.g. "-pod" or "!pod".
+func isRemoval(val string) bool {
+	return len(val) > 1 && (val[0] == '-' || val[0] == '!')
+}
+
+// Format: a,b,-c,!d
+func (s *stringSetValue) Set(val string) error {
+	v, err := readAsCSV(val)
+	if err != nil {
+		return err
+	}
+
+	set, err := s.mergeItems(*s.value, v, !s.changed)
+	if err != nil {
+		return err
+	}
+	*s.value = set
+	s.changed = true
+	return nil
+}
+
+// mergeItems adds items to the set and removes the removals among them. The
+// set is replaced if replace is set, unless the first item is a removal.
+func (s *stringSetValue) mergeItems(current, items []string, replace bool) ([]string, error) {
+	set := []string{}
+	if !replace || (len(items) > 0 && isRemoval(items[0])) {
+		set = append(set, current...)
+	}
+
+	for _, d := range items {
+		if isRemoval(d) {
+			member, err := s.canonical(d[1:])
+			if err != nil {
+				return nil, err
+			}
+			if i := s.indexOf(set, member); i >= 0 {
+				set = append(set[:i], set[i+1:]...)
+			}
+			continue
+		}
+
+		member, err := s.canonical(d)
+		if err != nil {
+			return nil, err
+		}
+		set = s.add(set, member)
+	}
+	return set, nil
+}
+
+func (s *stringSetValue) Get() interface{} {
+	return *s.value
+}
+
+func (s *stringSetValue) Type() string {
+	return "stringSet"
+}
+
+func (s *stringSetValue) String() string {
+	str, _ := writeAsCSV(*s.value)
+	return "[" + str + "]"
+}
+
+func (s *stringSetValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *stringSetValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *stringSetValue) appendValue(val string) error {
+	set, err := s.mergeItems(*s.value, []string{val}, false)
+	if err != nil {
+		return err
+	}
+	*s.value = set
+	return nil
+}
+
+func (s *stringSetValue) replaceValues(val []string) error {
+	set, err := s.mergeItems([]string{}, val, true)
+	if err != nil {
+		return err
+	}
+	*s.value = set
+	return nil
+}
+
+func (s *stringSetValue) GetSlice() []string {
+	return *s.value
+}
+
+func (s *stringSetValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
+// GetStringSet return the members of a string set flag with the given name
+func (f *FlagSet) GetStringSet(name string) ([]string, error) {
+	val, err := f.getFlagType(name, "stringSet")
+	if err != nil {
+		return []string{}, err
+	}
+	return val.([]string), nil
+}
+
+// MustGetStringSet is like GetStringSet, but panics on error.
+func (f *FlagSet) MustGetStringSet(name string) []string {
+	val, err := f.GetStringSet(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// StringSetVar defines a string set flag with specified name, default value, and usage string.
+// The argument p points to a []string variable in which to store the members of the set,
+// without duplicates and in the order they were first added, see OptSorted and OptMembers.
+// Like StringSlice flags, StringSet flags take comma-separated values. Members prefixed
+// with - or ! are removed, and a first argument starting with a removal modifies the
+// default set instead of replacing it.
+// For example, with the default set
+//
+//	[]string{"pod", "svc"}
+//
+// the arguments
+//
+//	--kinds=-pod,deploy --kinds=svc
+//
+// will result in
+//
+//	[]string{"svc", "deploy"}
+func (f *FlagSet) StringSetVar(p *[]string, name string, value []string, usage string, opts ...Opt) {
+	f.Var(newStringSetValue(value, p), name, usage, opts...)
+}
+
+// StringSetVar defines a string set flag with specified name, default value, and usage string.
+// The argument p points to a []string variable in which to store the members of the set,
+// without duplicates and in the order they were first added, see OptSorted and OptMembers.
+// Like StringSlice flags, StringSet flags take comma-separated values. Members prefixed
+// with - or ! are removed, and a first argument starting with a removal modifies the
+// default set instead of replacing it.
+// For example, with the default set
+//
+//	[]string{"pod", "svc"}
+//
+// the arguments
+//
+//	--kinds=-pod,deploy --kinds=svc
+//
+// will result in
+//
+//	[]string{"svc", "deploy"}
+func StringSetVar(p *[]string, name string, value []string, usage string, opts ...Opt) {
+	CommandLine.StringSetVar(p, name, value, usage, opts...)
+}
+
+// StringSet defines a string set flag with specified name, default value, and usage string.
+// The return value is the address of a []string variable that stores the members of the set.
+// See StringSetVar.
+func (f *FlagSet) StringSet(name string, value []string, usage string, opts ...Opt) *[]string {
+	var p []string
+	f.StringSetVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// StringSet defines a string set flag with specified name, default value, and usage string.
+// The return value is the address of a []string variable that stores the members of the set.
+// See StringSetVar.
+func StringSet(name string, value []string, usage string, opts ...Opt) *[]string {
+	return CommandLine.StringSet(name, value, usage, opts...)
+}
@@ -0,0 +1,167 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func setUpStringSetFlagSet(kinds *[]string, opts ...Opt) *FlagSet {
+	f := NewFlagSet("test", ContinueOnError)
+	f.StringSetVar(kinds, "kinds", []string{"pod", "svc"}, "resource kinds", opts...)
+	f.SetErrorPresentation(ErrorSilent)
+	return f
+}
+
+func TestStringSet(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected []string
+	}{
+		{nil, []string{"pod", "svc"}},
+		{[]string{"--kinds=deploy,pod,deploy"}, []string{"deploy", "pod"}},
+		{[]string{"--kinds=cm", "--kinds=pod,cm"}, []string{"cm", "pod"}},
+		{[]string{"--kinds=-pod"}, []string{"svc"}},
+		{[]string{"--kinds=!svc,deploy"}, []string{"pod", "deploy"}},
+		{[]string{"--kinds=-pod,deploy", "--kinds=svc,-deploy"}, []string{"svc"}},
+		{[]string{"--kinds=deploy,-pod"}, []string{"deploy"}},
+		{[]string{"--kinds="}, []string{}},
+	}
+	for _, test := range tests {
+		var kinds []string
+		f := setUpStringSetFlagSet(&kinds)
+		if err := f.Parse(test.args); err != nil {
+			t.Errorf("%v: %v", test.args, err)
+			continue
+		}
+		if !reflect.DeepEqual(kinds, test.expected) {
+			t.Errorf("%v: expected %v; got %v", test.args, test.expected, kinds)
+		}
+		if v := f.MustGetStringSet("kinds"); !reflect.DeepEqual(v, test.expected) {
+			t.Errorf("%v: expected the getter to return %v; got %v", test.args, test.expected, v)
+		}
+	}
+}
+
+func TestStringSetSorted(t *testing.T) {
+	var kinds []string
+	f := setUpStringSetFlagSet(&kinds, OptSorted())
+	if err := f.Parse([]string{"--kinds=svc,deploy,cm,svc"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"cm", "deploy", "svc"}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Errorf("expected %v; got %v", expected, kinds)
+	}
+}
+
+func TestStringSetMembers(t *testing.T) {
+	var kinds []string
+	f := setUpStringSetFlagSet(&kinds, OptMembers("pod", "svc", "deploy"), OptCaseInsensitive())
+	if err := f.Parse([]string{"--kinds=-POD,Deploy"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"svc", "deploy"}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Errorf("expected %v; got %v", expected, kinds)
+	}
+
+	tests := []struct {
+		arg      string
+		expected string
+	}{
+		{"--kinds=pod,deplyo", `invalid argument "pod,deplyo" for "--kinds" flag: "deplyo" is not one of pod|svc|deploy, did you mean "deploy"?`},
+		{"--kinds=-cm", `invalid argument "-cm" for "--kinds" flag: "cm" is not one of pod|svc|deploy`},
+	}
+	for _, test := range tests {
+		err := f.Parse([]string{test.arg})
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("%s: expected %q; got %v", test.arg, test.expected, err)
+		}
+	}
+
+	usage := "      --kinds {pod|svc|deploy}   resource kinds (default [pod,svc])\n"
+	if v := f.FlagUsages(); v != usage {
+		t.Errorf("expected:\n%q\ngot:\n%q", usage, v)
+	}
+}
+
+func TestStringSetInvalidMembers(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a default value that is not a member")
+		}
+	}()
+	var kinds []string
+	setUpStringSetFlagSet(&kinds, OptMembers("pod", "deploy"))
+}
+
+func TestStringSetAppendReplace(t *testing.T) {
+	var kinds []string
+	f := setUpStringSetFlagSet(&kinds)
+	v := f.Lookup("kinds").Value.(SliceValue)
+
+	if err := v.Append("pod"); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Append("deploy"); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"pod", "svc", "deploy"}
+	if !reflect.DeepEqual(v.GetSlice(), expected) {
+		t.Errorf("expected %v; got %v", expected, v.GetSlice())
+	}
+
+	if err := v.Replace([]string{"cm", "cm", "pod"}); err != nil {
+		t.Fatal(err)
+	}
+	expected = []string{"cm", "pod"}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Errorf("expected %v; got %v", expected, kinds)
+	}
+
+	usage := "      --kinds strings   resource kinds (default [pod,svc])\n"
+	if u := f.FlagUsages(); u != usage {
+		t.Errorf("expected:\n%q\ngot:\n%q", usage, u)
+	}
+}
+
+func TestStringSetCaseInsensitive(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	kinds := f.StringSet("kinds", []string{"Pod", "POD"}, "kinds", OptCaseInsensitive())
+	if f.Lookup("kinds").DefValue != "[Pod]" {
+		t.Errorf("expected a default without duplicates; got %s", f.Lookup("kinds").DefValue)
+	}
+
+	if err := f.Parse([]string{"--kinds=Pod,pod,svc", "--kinds=-SVC"}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*kinds, []string{"Pod"}) {
+		t.Errorf("expected duplicates to be detected regardless of case; got %v", *kinds)
+	}
+}
+
+func TestStringSetAppendReplaceRemovals(t *testing.T) {
+	f := NewFlagSet("test", ContinueOnError)
+	kinds := f.StringSet("kinds", []string{"pod", "svc"}, "kinds")
+	s := f.Lookup("kinds").Value.(SliceValue)
+
+	if err := s.Append("-pod"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Append("deploy"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*kinds, []string{"svc", "deploy"}) {
+		t.Errorf("expected Append to remove like Set; got %v", *kinds)
+	}
+
+	if err := s.Replace([]string{"pod", "job", "-pod"}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*kinds, []string{"job"}) {
+		t.Errorf("expected Replace to remove like Set; got %v", *kinds)
+	}
+}
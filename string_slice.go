@@ -13,6 +13,7 @@ import (
 
 // -- stringSlice Value
 type stringSliceValue struct {
+	sliceLimits
 	value   *[]string
 	changed bool
 }
@@ -90,11 +91,19 @@ func (s *stringSliceValue) String() string {
 }
 
 func (s *stringSliceValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *stringSliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *stringSliceValue) appendValue(val string) error {
 	*s.value = append(*s.value, val)
 	return nil
 }
 
-func (s *stringSliceValue) Replace(val []string) error {
+func (s *stringSliceValue) replaceValues(val []string) error {
 	*s.value = val
 	return nil
 }
@@ -103,6 +112,11 @@ func (s *stringSliceValue) GetSlice() []string {
 	return *s.value
 }
 
+func (s *stringSliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetStringSlice return the []string value of a flag with the given name
 func (f *FlagSet) GetStringSlice(name string) ([]string, error) {
 	val, err := f.getFlagType(name, "stringSlice")
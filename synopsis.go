@@ -0,0 +1,118 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"sort"
+	"strings"
+)
+
+// nbsp keeps the parts of a synopsis item together while wrapping.
+const nbsp = "\u00a0"
+
+// isBoolFlag reports whether flag can be passed without a value and takes no
+// value in its usual form, such as bool and count flags.
+func isBoolFlag(flag *Flag) bool {
+	if flag.NoOptDefVal == "" {
+		return false
+	}
+	if v, ok := flag.Value.(boolFlag); ok {
+		return v.IsBoolFlag()
+	}
+	if v, ok := flag.Value.(Typed); ok && v.Type() == "count" {
+		return true
+	}
+	return false
+}
+
+// synopsisItems returns the flags of the synopsis, with the parts of each
+// item joined by nbsp.
+func (f *FlagSet) synopsisItems() []string {
+	var cluster []rune
+	var items []string
+
+	if !f.DisableBuiltinHelp && f.ShorthandLookup('h') == nil && f.Lookup("help") == nil {
+		cluster = append(cluster, 'h')
+	}
+
+	f.VisitAll(func(flag *Flag) {
+		if f.isHiddenFlag(flag) || flag.Visibility > f.HelpVisibility || flag.IsDeprecated() {
+			return
+		}
+
+		hasShorthand := flag.Shorthand != 0 && flag.ShorthandDeprecated == ""
+		if hasShorthand && isBoolFlag(flag) && !flag.ShowRequired {
+			cluster = append(cluster, flag.Shorthand)
+			return
+		}
+
+		var item string
+		if hasShorthand {
+			item = "-" + string(flag.Shorthand)
+		} else {
+			item = "--" + flag.Name
+		}
+
+		varname, _ := UnquoteUsage(flag)
+		switch {
+		case isBoolFlag(flag) || varname == "":
+		case flag.NoOptDefVal != "":
+			if hasShorthand {
+				item += "[" + varname + "]"
+			} else {
+				item += "[=" + varname + "]"
+			}
+		default:
+			item += nbsp + varname
+		}
+
+		if !flag.ShowRequired {
+			item = "[" + item + "]"
+		}
+		items = append(items, item)
+	})
+
+	if len(cluster) > 0 {
+		if f.SortFlags {
+			sort.Slice(cluster, func(i, j int) bool { return cluster[i] < cluster[j] })
+		}
+		items = append([]string{"[-" + string(cluster) + "]"}, items...)
+	}
+	if f.ArgsUsage != "" {
+		items = append(items, f.ArgsUsage)
+	}
+
+	return items
+}
+
+// synopsis returns the synopsis wrapped to `cols` columns, assuming it is
+// printed after `prefix` columns of other text.
+func (f *FlagSet) synopsis(prefix, cols int) string {
+	items := strings.Join(f.synopsisItems(), " ")
+	if items == "" {
+		return f.name
+	}
+
+	indent := prefix + displayWidth(f.name) + 1
+	return strings.Replace(f.name+" "+wrap(indent, cols, items), nbsp, " ", -1)
+}
+
+// Synopsis returns a conventional one-line summary of the command line
+// accepted by the FlagSet, such as
+//
+//	tool [-hv] [-o file] [--level int] ARGS...
+//
+// Boolean shorthands are clustered, flags with OptShowRequired are shown
+// without brackets and flags with a NoOptDefVal are shown with an optional value,
+// e.g. [--color[=when]]. Hidden flags are left out. The positional
+// arguments are described by ArgsUsage.
+func (f *FlagSet) Synopsis() string {
+	return f.synopsis(0, 0)
+}
+
+// SynopsisWrapped returns the Synopsis wrapped to `cols` columns (0 for no
+// wrapping, AutoWrap for the width of the terminal).
+func (f *FlagSet) SynopsisWrapped(cols int) string {
+	return f.synopsis(0, f.usageCols(cols))
+}
@@ -0,0 +1,56 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"testing"
+)
+
+func TestSynopsis(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.ArgsUsage = "ARGS..."
+	fs.Bool("verbose", false, "verbose output", OptShorthand('v'))
+	fs.Count("debug", "debug level", OptShorthand('d'))
+	fs.String("output", "", "output `file`", OptShorthand('o'))
+	fs.Int("level", 0, "log level")
+	fs.String("color", "auto", "colorize output (`when`)", OptNoOptDefVal("always"))
+	fs.String("config", "", "config `path`", OptShowRequired())
+	fs.Bool("dry-run", false, "only print what would be done")
+	fs.Bool("secret", false, "secret", OptHidden())
+
+	want := "tool [-dhv] [--color[=when]] --config path [--dry-run] [--level int] [-o file] ARGS..."
+	if got := fs.Synopsis(); got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
+
+func TestSynopsisWithoutFlags(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.DisableBuiltinHelp = true
+
+	if got, want := fs.Synopsis(), "tool"; got != want {
+		t.Errorf("expected %q; got %q", want, got)
+	}
+
+	fs.ArgsUsage = "FILE"
+	if got, want := fs.Synopsis(), "tool FILE"; got != want {
+		t.Errorf("expected %q; got %q", want, got)
+	}
+}
+
+func TestSynopsisWrapped(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.ArgsUsage = "FILE..."
+	fs.String("output", "", "output `file`", OptShorthand('o'))
+	fs.String("input-format", "", "input `format`")
+	fs.String("output-format", "", "output `format`")
+	fs.Int("workers", 0, "number of workers")
+
+	want := `tool [-h] [--input-format format] [-o file]
+     [--output-format format]
+     [--workers int] FILE...`
+	if got := fs.SynopsisWrapped(50); got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
@@ -0,0 +1,60 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// AutoWrap can be passed as the number of columns to the wrapping usage
+// functions (e.g. FlagUsagesWrapped) to wrap to the width of the terminal
+// the FlagSet's Output() is connected to. See TerminalWidth.
+const AutoWrap = -1
+
+// DefaultTerminalWidth is the width used by TerminalWidth if neither the
+// terminal nor the COLUMNS environment variable provide one.
+const DefaultTerminalWidth = 80
+
+// fder is implemented by writers backed by a file descriptor, such as *os.File.
+type fder interface {
+	Fd() uintptr
+}
+
+// TerminalWidth returns the number of columns available to w. If w is a
+// terminal its width is queried, otherwise the COLUMNS environment variable
+// is used. If neither is available, DefaultTerminalWidth is returned.
+func TerminalWidth(w io.Writer) int {
+	if fw, ok := w.(fder); ok {
+		if cols, ok := terminalWidth(fw.Fd()); ok && cols > 0 {
+			return cols
+		}
+	}
+
+	if cols, err := strconv.Atoi(strings.TrimSpace(os.Getenv("COLUMNS"))); err == nil && cols > 0 {
+		return cols
+	}
+
+	return DefaultTerminalWidth
+}
+
+// isTerminal reports whether w is a terminal.
+func isTerminal(w io.Writer) bool {
+	fw, ok := w.(fder)
+	if !ok {
+		return false
+	}
+	_, ok = terminalWidth(fw.Fd())
+	return ok
+}
+
+// usageCols resolves AutoWrap to the width of the FlagSet's output.
+func (f *FlagSet) usageCols(cols int) int {
+	if cols == AutoWrap {
+		return TerminalWidth(f.Output())
+	}
+	return cols
+}
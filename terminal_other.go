@@ -0,0 +1,12 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux && !darwin && !freebsd && !netbsd && !dragonfly
+// +build !linux,!darwin,!freebsd,!netbsd,!dragonfly
+
+package zflag
+
+// terminalWidth is not supported on this platform.
+func terminalWidth(fd uintptr) (int, bool) {
+	return 0, false
+}
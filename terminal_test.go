@@ -0,0 +1,62 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestTerminalWidth(t *testing.T) {
+	oldColumns, hadColumns := os.LookupEnv("COLUMNS")
+	defer func() {
+		if hadColumns {
+			os.Setenv("COLUMNS", oldColumns)
+		} else {
+			os.Unsetenv("COLUMNS")
+		}
+	}()
+
+	var buf bytes.Buffer
+
+	os.Setenv("COLUMNS", "123")
+	if got := TerminalWidth(&buf); got != 123 {
+		t.Errorf("expected width from COLUMNS; got %d", got)
+	}
+
+	os.Setenv("COLUMNS", "invalid")
+	if got := TerminalWidth(&buf); got != DefaultTerminalWidth {
+		t.Errorf("expected fallback width; got %d", got)
+	}
+
+	os.Unsetenv("COLUMNS")
+	if got := TerminalWidth(&buf); got != DefaultTerminalWidth {
+		t.Errorf("expected fallback width; got %d", got)
+	}
+}
+
+func TestFlagUsagesAutoWrap(t *testing.T) {
+	oldColumns, hadColumns := os.LookupEnv("COLUMNS")
+	defer func() {
+		if hadColumns {
+			os.Setenv("COLUMNS", oldColumns)
+		} else {
+			os.Unsetenv("COLUMNS")
+		}
+	}()
+	os.Setenv("COLUMNS", "80")
+
+	var buf bytes.Buffer
+	f := setUpZFlagSet2(&buf)
+	if got := f.FlagUsagesWrapped(AutoWrap); got != expectedOutput2 {
+		t.Errorf("expected \n%q \nactual \n%q", expectedOutput2, got)
+	}
+
+	os.Setenv("COLUMNS", "200")
+	if got := f.FlagUsagesWrapped(AutoWrap); strings.Count(got, "\n") >= strings.Count(expectedOutput2, "\n") {
+		t.Errorf("expected fewer lines for a wider terminal; got \n%s", got)
+	}
+}
@@ -6,28 +6,31 @@ package zflag
 import "strconv"
 
 // -- uint Value
-type uintValue uint
+type uintValue struct {
+	numberParser
+	value *uint
+}
 
 func newUintValue(val uint, p *uint) *uintValue {
 	*p = val
-	return (*uintValue)(p)
+	return &uintValue{value: p}
 }
 
 func (i *uintValue) Set(s string) error {
-	v, err := strconv.ParseUint(s, 0, 64)
-	*i = uintValue(v)
+	v, err := i.parseUint(s, 0)
+	*i.value = uint(v)
 	return err
 }
 
 func (i *uintValue) Get() interface{} {
-	return uint(*i)
+	return *i.value
 }
 
 func (i *uintValue) Type() string {
 	return "uint"
 }
 
-func (i *uintValue) String() string { return strconv.FormatUint(uint64(*i), 10) }
+func (i *uintValue) String() string { return strconv.FormatUint(uint64(*i.value), 10) }
 
 // GetUint return the uint value of a flag with the given name
 func (f *FlagSet) GetUint(name string) (uint, error) {
@@ -6,28 +6,31 @@ package zflag
 import "strconv"
 
 // -- uint16 value
-type uint16Value uint16
+type uint16Value struct {
+	numberParser
+	value *uint16
+}
 
 func newUint16Value(val uint16, p *uint16) *uint16Value {
 	*p = val
-	return (*uint16Value)(p)
+	return &uint16Value{value: p}
 }
 
 func (i *uint16Value) Set(s string) error {
-	v, err := strconv.ParseUint(s, 0, 16)
-	*i = uint16Value(v)
+	v, err := i.parseUint(s, 16)
+	*i.value = uint16(v)
 	return err
 }
 
 func (i *uint16Value) Get() interface{} {
-	return uint16(*i)
+	return *i.value
 }
 
 func (i *uint16Value) Type() string {
 	return "uint16"
 }
 
-func (i *uint16Value) String() string { return strconv.FormatUint(uint64(*i), 10) }
+func (i *uint16Value) String() string { return strconv.FormatUint(uint64(*i.value), 10) }
 
 // GetUint16 return the uint16 value of a flag with the given name
 func (f *FlagSet) GetUint16(name string) (uint16, error) {
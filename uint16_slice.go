@@ -5,12 +5,13 @@ package zflag
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 )
 
 // -- uint16Slice Value
 type uint16SliceValue struct {
+	numberParser
+	sliceLimits
 	value   *[]uint16
 	changed bool
 }
@@ -31,13 +32,10 @@ func (s *uint16SliceValue) Set(val string) error {
 	out := make([]uint16, len(ss))
 	for i, d := range ss {
 		var err error
-		var temp64 uint64
-		temp64, err = strconv.ParseUint(d, 0, 16)
+		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
-		out[i] = uint16(temp64)
-
 	}
 	if !s.changed {
 		*s.value = out
@@ -61,7 +59,7 @@ func (s *uint16SliceValue) String() string {
 }
 
 func (s *uint16SliceValue) fromString(val string) (uint16, error) {
-	t64, err := strconv.ParseUint(val, 0, 16)
+	t64, err := s.parseUint(val, 16)
 	if err != nil {
 		return 0, err
 	}
@@ -73,6 +71,14 @@ func (s *uint16SliceValue) toString(val uint16) string {
 }
 
 func (s *uint16SliceValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *uint16SliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *uint16SliceValue) appendValue(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
 		return err
@@ -81,13 +87,13 @@ func (s *uint16SliceValue) Append(val string) error {
 	return nil
 }
 
-func (s *uint16SliceValue) Replace(val []string) error {
+func (s *uint16SliceValue) replaceValues(val []string) error {
 	out := make([]uint16, len(val))
 	for i, d := range val {
 		var err error
 		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
 	}
 	*s.value = out
@@ -102,6 +108,11 @@ func (s *uint16SliceValue) GetSlice() []string {
 	return out
 }
 
+func (s *uint16SliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetUint16Slice return the []uint16 value of a flag with the given name
 func (f *FlagSet) GetUint16Slice(name string) ([]uint16, error) {
 	val, err := f.getFlagType(name, "uint16Slice")
@@ -6,28 +6,31 @@ package zflag
 import "strconv"
 
 // -- uint32 value
-type uint32Value uint32
+type uint32Value struct {
+	numberParser
+	value *uint32
+}
 
 func newUint32Value(val uint32, p *uint32) *uint32Value {
 	*p = val
-	return (*uint32Value)(p)
+	return &uint32Value{value: p}
 }
 
 func (i *uint32Value) Set(s string) error {
-	v, err := strconv.ParseUint(s, 0, 32)
-	*i = uint32Value(v)
+	v, err := i.parseUint(s, 32)
+	*i.value = uint32(v)
 	return err
 }
 
 func (i *uint32Value) Get() interface{} {
-	return uint32(*i)
+	return *i.value
 }
 
 func (i *uint32Value) Type() string {
 	return "uint32"
 }
 
-func (i *uint32Value) String() string { return strconv.FormatUint(uint64(*i), 10) }
+func (i *uint32Value) String() string { return strconv.FormatUint(uint64(*i.value), 10) }
 
 // GetUint32 return the uint32 value of a flag with the given name
 func (f *FlagSet) GetUint32(name string) (uint32, error) {
@@ -6,28 +6,31 @@ package zflag
 import "strconv"
 
 // -- uint64 Value
-type uint64Value uint64
+type uint64Value struct {
+	numberParser
+	value *uint64
+}
 
 func newUint64Value(val uint64, p *uint64) *uint64Value {
 	*p = val
-	return (*uint64Value)(p)
+	return &uint64Value{value: p}
 }
 
 func (i *uint64Value) Set(s string) error {
-	v, err := strconv.ParseUint(s, 0, 64)
-	*i = uint64Value(v)
+	v, err := i.parseUint(s, 64)
+	*i.value = uint64(v)
 	return err
 }
 
 func (i *uint64Value) Get() interface{} {
-	return uint64(*i)
+	return *i.value
 }
 
 func (i *uint64Value) Type() string {
 	return "uint64"
 }
 
-func (i *uint64Value) String() string { return strconv.FormatUint(uint64(*i), 10) }
+func (i *uint64Value) String() string { return strconv.FormatUint(*i.value, 10) }
 
 // GetUint64 return the uint64 value of a flag with the given name
 func (f *FlagSet) GetUint64(name string) (uint64, error) {
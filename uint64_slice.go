@@ -5,12 +5,13 @@ package zflag
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 )
 
 // -- uint64Slice Value
 type uint64SliceValue struct {
+	numberParser
+	sliceLimits
 	value   *[]uint64
 	changed bool
 }
@@ -31,11 +32,10 @@ func (s *uint64SliceValue) Set(val string) error {
 	out := make([]uint64, len(ss))
 	for i, d := range ss {
 		var err error
-		out[i], err = strconv.ParseUint(d, 0, 64)
+		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
-
 	}
 	if !s.changed {
 		*s.value = out
@@ -59,7 +59,7 @@ func (s *uint64SliceValue) String() string {
 }
 
 func (s *uint64SliceValue) fromString(val string) (uint64, error) {
-	return strconv.ParseUint(val, 0, 64)
+	return s.parseUint(val, 64)
 }
 
 func (s *uint64SliceValue) toString(val uint64) string {
@@ -67,6 +67,14 @@ func (s *uint64SliceValue) toString(val uint64) string {
 }
 
 func (s *uint64SliceValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *uint64SliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *uint64SliceValue) appendValue(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
 		return err
@@ -75,13 +83,13 @@ func (s *uint64SliceValue) Append(val string) error {
 	return nil
 }
 
-func (s *uint64SliceValue) Replace(val []string) error {
+func (s *uint64SliceValue) replaceValues(val []string) error {
 	out := make([]uint64, len(val))
 	for i, d := range val {
 		var err error
 		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
 	}
 	*s.value = out
@@ -96,6 +104,11 @@ func (s *uint64SliceValue) GetSlice() []string {
 	return out
 }
 
+func (s *uint64SliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetUint64Slice return the []uint64 value of a flag with the given name
 func (f *FlagSet) GetUint64Slice(name string) ([]uint64, error) {
 	val, err := f.getFlagType(name, "uint64Slice")
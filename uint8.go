@@ -6,28 +6,31 @@ package zflag
 import "strconv"
 
 // -- uint8 Value
-type uint8Value uint8
+type uint8Value struct {
+	numberParser
+	value *uint8
+}
 
 func newUint8Value(val uint8, p *uint8) *uint8Value {
 	*p = val
-	return (*uint8Value)(p)
+	return &uint8Value{value: p}
 }
 
 func (i *uint8Value) Set(s string) error {
-	v, err := strconv.ParseUint(s, 0, 8)
-	*i = uint8Value(v)
+	v, err := i.parseUint(s, 8)
+	*i.value = uint8(v)
 	return err
 }
 
 func (i *uint8Value) Get() interface{} {
-	return uint8(*i)
+	return *i.value
 }
 
 func (i *uint8Value) Type() string {
 	return "uint8"
 }
 
-func (i *uint8Value) String() string { return strconv.FormatUint(uint64(*i), 10) }
+func (i *uint8Value) String() string { return strconv.FormatUint(uint64(*i.value), 10) }
 
 // GetUint8 return the uint8 value of a flag with the given name
 func (f *FlagSet) GetUint8(name string) (uint8, error) {
@@ -5,12 +5,13 @@ package zflag
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 )
 
 // -- uint8Slice Value
 type uint8SliceValue struct {
+	numberParser
+	sliceLimits
 	value   *[]uint8
 	changed bool
 }
@@ -31,13 +32,10 @@ func (s *uint8SliceValue) Set(val string) error {
 	out := make([]uint8, len(ss))
 	for i, d := range ss {
 		var err error
-		var temp64 uint64
-		temp64, err = strconv.ParseUint(d, 0, 8)
+		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
-		out[i] = uint8(temp64)
-
 	}
 	if !s.changed {
 		*s.value = out
@@ -61,7 +59,7 @@ func (s *uint8SliceValue) String() string {
 }
 
 func (s *uint8SliceValue) fromString(val string) (uint8, error) {
-	t64, err := strconv.ParseUint(val, 0, 8)
+	t64, err := s.parseUint(val, 8)
 	if err != nil {
 		return 0, err
 	}
@@ -73,6 +71,14 @@ func (s *uint8SliceValue) toString(val uint8) string {
 }
 
 func (s *uint8SliceValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *uint8SliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *uint8SliceValue) appendValue(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
 		return err
@@ -81,13 +87,13 @@ func (s *uint8SliceValue) Append(val string) error {
 	return nil
 }
 
-func (s *uint8SliceValue) Replace(val []string) error {
+func (s *uint8SliceValue) replaceValues(val []string) error {
 	out := make([]uint8, len(val))
 	for i, d := range val {
 		var err error
 		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
 	}
 	*s.value = out
@@ -102,6 +108,11 @@ func (s *uint8SliceValue) GetSlice() []string {
 	return out
 }
 
+func (s *uint8SliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetUint8Slice return the []uint8 value of a flag with the given name
 func (f *FlagSet) GetUint8Slice(name string) ([]uint8, error) {
 	val, err := f.getFlagType(name, "uint8Slice")
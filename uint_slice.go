@@ -5,18 +5,20 @@ package zflag
 
 import (
 	"fmt"
-	"strconv"
 	"strings"
 )
 
 // -- uintSlice Value
 type uintSliceValue struct {
+	numberParser
+	sliceLimits
 	value   *[]uint
 	changed bool
 }
 
 func newUintSliceValue(val []uint, p *[]uint) *uintSliceValue {
 	uisv := new(uintSliceValue)
+	uisv.decimalZero = true
 	uisv.value = p
 	*uisv.value = val
 	return uisv
@@ -26,11 +28,11 @@ func (s *uintSliceValue) Set(val string) error {
 	ss := strings.Split(val, ",")
 	out := make([]uint, len(ss))
 	for i, d := range ss {
-		u, err := strconv.ParseUint(d, 10, 0)
+		var err error
+		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
-		out[i] = uint(u)
 	}
 	if !s.changed {
 		*s.value = out
@@ -58,11 +60,11 @@ func (s *uintSliceValue) String() string {
 }
 
 func (s *uintSliceValue) fromString(val string) (uint, error) {
-	t, err := strconv.ParseUint(val, 10, 0)
+	t64, err := s.parseUint(val, 0)
 	if err != nil {
 		return 0, err
 	}
-	return uint(t), nil
+	return uint(t64), nil
 }
 
 func (s *uintSliceValue) toString(val uint) string {
@@ -70,6 +72,14 @@ func (s *uintSliceValue) toString(val uint) string {
 }
 
 func (s *uintSliceValue) Append(val string) error {
+	return s.limitAppend(s, val)
+}
+
+func (s *uintSliceValue) Replace(val []string) error {
+	return s.limitReplace(s, val)
+}
+
+func (s *uintSliceValue) appendValue(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
 		return err
@@ -78,13 +88,13 @@ func (s *uintSliceValue) Append(val string) error {
 	return nil
 }
 
-func (s *uintSliceValue) Replace(val []string) error {
+func (s *uintSliceValue) replaceValues(val []string) error {
 	out := make([]uint, len(val))
 	for i, d := range val {
 		var err error
 		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return indexError(i, err)
 		}
 	}
 	*s.value = out
@@ -99,6 +109,11 @@ func (s *uintSliceValue) GetSlice() []string {
 	return out
 }
 
+func (s *uintSliceValue) snapshot() func() {
+	value, changed := append((*s.value)[:0:0], *s.value...), s.changed
+	return func() { *s.value, s.changed = value, changed }
+}
+
 // GetUintSlice returns the []uint value of a flag with the given name.
 func (f *FlagSet) GetUintSlice(name string) ([]uint, error) {
 	val, err := f.getFlagType(name, "uintSlice")
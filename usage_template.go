@@ -0,0 +1,186 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// DefaultUsageTemplate reproduces the default usage message of a FlagSet
+// using the TextHelpRenderer. It can be used as a starting point for custom
+// usage templates, see FlagSet.UsageTemplate.
+const DefaultUsageTemplate = `{{if .Name}}Usage: {{.Synopsis}}
+{{if .HasAvailableFlags}}
+{{end}}{{else}}Usage:
+{{end}}{{range $i, $group := .Groups}}{{if $i}}
+{{end}}{{with $group.Header}}{{.}}
+{{end}}{{with $group.Description}}  {{wrap 2 $.Cols .}}
+{{end}}{{range $group.Rows}}{{pad $.NameWidth .NameColumn}}   {{wrap (add $.NameWidth 3) $.Cols .UsageColumn}}
+{{end}}{{end}}{{with .Footer}}
+{{wrap 0 $.Cols .}}
+{{end}}{{with .Examples}}
+Examples:
+{{range .}}{{indent 2 .}}
+{{end}}{{end}}`
+
+// UsageData is the data passed to usage templates.
+type UsageData struct {
+	// Name is the name of the FlagSet.
+	Name string
+	// Synopsis is the synopsis of the FlagSet, see FlagSet.Synopsis.
+	Synopsis string
+	// ArgsUsage describes the positional arguments, see FlagSet.ArgsUsage.
+	ArgsUsage string
+	// Examples are the example invocations of the FlagSet, see FlagSet.Examples.
+	Examples []string
+	// Cols is the number of columns to wrap to, 0 for no wrapping.
+	Cols int
+
+	// HasAvailableFlags reports whether there are flags that are not hidden.
+	HasAvailableFlags bool
+	// Flags holds all flags shown in the usage message, in the same order
+	// as VisitAll.
+	Flags []*Flag
+	// NameWidth is the display width of the widest name column, see HelpModel.
+	NameWidth int
+	// Groups holds the formatted flags per group, see HelpModel.
+	Groups []HelpGroup
+	// Footer is the note printed below all groups, see HelpModel.
+	Footer string
+	// FlagUsages is the output of the FlagSet's HelpRenderer for all groups.
+	FlagUsages string
+}
+
+// UsageData returns the data passed to usage templates, wrapped to `cols`
+// columns (0 for no wrapping, AutoWrap for the width of the terminal).
+func (f *FlagSet) UsageData(cols int) *UsageData {
+	cols = f.usageCols(cols)
+	model := f.HelpModel()
+
+	data := &UsageData{
+		Name:              f.name,
+		Synopsis:          f.synopsis(len("Usage: "), cols),
+		ArgsUsage:         f.ArgsUsage,
+		Examples:          f.Examples,
+		Cols:              cols,
+		HasAvailableFlags: f.HasAvailableFlags(),
+		NameWidth:         model.NameWidth,
+		Groups:            model.Groups,
+		Footer:            model.Footer,
+	}
+	for _, group := range model.Groups {
+		for _, row := range group.Rows {
+			data.Flags = append(data.Flags, row.Flag)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := f.helpRenderer().Render(buf, model, cols); err != nil {
+		fmt.Fprintln(f.Output(), err)
+	}
+	data.FlagUsages = buf.String()
+
+	return data
+}
+
+// UsageTemplateFuncs returns the functions available in usage templates:
+//
+//	wrap INDENT COLS TEXT   wraps TEXT like the usage column of flags
+//	indent N TEXT           indents every line of TEXT by N spaces
+//	pad WIDTH TEXT          pads TEXT with spaces to a display width of WIDTH
+//	add A B                 returns A + B
+//	terminalWidth           returns the width of the FlagSet's output, see TerminalWidth
+//	flagName FLAG           the FlagUsageFormatter fragments of a flag
+//	flagVarName FLAG
+//	flagUsage FLAG
+//	flagConstraint FLAG
+//	flagDefaultValue FLAG
+//	flagNoOptDefValue FLAG
+//	flagExperimental FLAG
+//	flagDeprecated FLAG
+//	groupHeader TITLE
+func (f *FlagSet) UsageTemplateFuncs() template.FuncMap {
+	usageFormatter := f.flagUsageFormatter()
+
+	return template.FuncMap{
+		"wrap": wrap,
+		"indent": func(n int, s string) string {
+			prefix := strings.Repeat(" ", n)
+			return prefix + strings.Replace(s, "\n", "\n"+prefix, -1)
+		},
+		"pad": func(width int, s string) string {
+			if w := displayWidth(s); w < width {
+				return s + strings.Repeat(" ", width-w)
+			}
+			return s
+		},
+		"add": func(a, b int) int { return a + b },
+		"terminalWidth": func() int {
+			return TerminalWidth(f.Output())
+		},
+		"flagName": usageFormatter.Name,
+		"flagVarName": func(flag *Flag) string {
+			varname, _ := UnquoteUsage(flag)
+			if varname == "" {
+				return ""
+			}
+			return usageFormatter.UsageVarName(flag, varname)
+		},
+		"flagUsage": func(flag *Flag) string {
+			_, usage := UnquoteUsage(flag)
+			return usageFormatter.Usage(flag, usage)
+		},
+		"flagConstraint": func(flag *Flag) string {
+			if flag.Constraint() == "" {
+				return ""
+			}
+			return constraintNote(usageFormatter, flag)
+		},
+		"flagDefaultValue": func(flag *Flag) string {
+			if flag.DisablePrintDefault || flag.defaultIsZeroValue() {
+				return ""
+			}
+			return usageFormatter.DefaultValue(flag)
+		},
+		"flagNoOptDefValue": func(flag *Flag) string {
+			if flag.NoOptDefVal == "" {
+				return ""
+			}
+			return usageFormatter.NoOptDefValue(flag)
+		},
+		"flagExperimental": func(flag *Flag) string {
+			if flag.Experimental == "" {
+				return ""
+			}
+			return experimentalNote(usageFormatter, flag)
+		},
+		"flagDeprecated": func(flag *Flag) string {
+			if !flag.IsDeprecated() {
+				return ""
+			}
+			return usageFormatter.Deprecated(flag)
+		},
+		"groupHeader": func(title string) string {
+			if h, ok := usageFormatter.(GroupHeaderFormatter); ok {
+				return h.GroupHeader(title)
+			}
+			return title + ":"
+		},
+	}
+}
+
+// ExecuteUsageTemplate writes the usage message produced by the template text
+// to w. See UsageData for the data and UsageTemplateFuncs for the functions
+// available in the template.
+func (f *FlagSet) ExecuteUsageTemplate(w io.Writer, text string, cols int) error {
+	tmpl, err := template.New(f.name).Funcs(f.UsageTemplateFuncs()).Parse(text)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, f.UsageData(cols))
+}
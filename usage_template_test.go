@@ -0,0 +1,127 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDefaultUsageTemplate(t *testing.T) {
+	newFlagSets := map[string]func() *FlagSet{
+		"unnamed": func() *FlagSet {
+			fs := NewFlagSet("", ContinueOnError)
+			fs.String("name", "bob", "the `who` to greet", OptShorthand('n'))
+			return fs
+		},
+		"empty": func() *FlagSet {
+			return NewFlagSet("test", ContinueOnError)
+		},
+		"groups": func() *FlagSet {
+			fs := NewFlagSet("test", ContinueOnError)
+			fs.ArgsUsage = "FILE..."
+			fs.AddGroup("net", "Network", "Options for the network connection.", 0)
+			fs.String("name", "bob", "the `who` to greet", OptShorthand('n'))
+			fs.Int("port", 80, "port to connect to", OptGroup("net"))
+			fs.Bool("trace", false, "trace requests", OptGroup("net"), OptVisibility(VisibilityDebug))
+			fs.Bool("old", false, "old flag", OptDeprecated("use --name"))
+			fs.Examples = []string{"test -n alice", "test --port 8080 \\\n  file.txt"}
+			return fs
+		},
+		"go layout": func() *FlagSet {
+			fs := NewFlagSet("test", ContinueOnError)
+			fs.Bool("v", false, "verbose")
+			fs.String("name", "bob", "the `who` to greet")
+			fs.FlagUsageFormatter = GoFlagUsageFormatter{}
+			return fs
+		},
+	}
+
+	for name, newFlagSet := range newFlagSets {
+		t.Run(name, func(t *testing.T) {
+			fs := newFlagSet()
+			expected := new(bytes.Buffer)
+			fs.SetOutput(expected)
+			fs.usage()
+
+			fs = newFlagSet()
+			fs.UsageTemplate = DefaultUsageTemplate
+			got := new(bytes.Buffer)
+			fs.SetOutput(got)
+			fs.usage()
+
+			if got.String() != expected.String() {
+				t.Errorf("expected:\n%s\ngot:\n%s", expected.String(), got.String())
+			}
+		})
+	}
+}
+
+func TestUsageTemplate(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.ArgsUsage = "FILE"
+	fs.String("name", "bob", "the `who` to greet", OptShorthand('n'))
+	fs.Int("level", 0, "the level", OptNoOptDefVal("3"))
+	fs.Bool("old", false, "old flag", OptDeprecated("use --name"))
+	fs.UsageTemplate = `{{.Name}} - greets people
+{{range .Flags}}{{flagName .}}|{{flagVarName .}}|{{flagUsage .}}|{{flagDefaultValue .}}|{{flagNoOptDefValue .}}
+{{end}}{{pad 6 .ArgsUsage}}|
+{{indent 2 "a\nb"}}
+{{groupHeader "Other"}}
+{{wrap 2 30 "one two three four five six seven eight nine ten"}}
+`
+
+	buf := new(bytes.Buffer)
+	fs.SetOutput(buf)
+	err := fs.Parse([]string{"--help"})
+	if err != ErrHelp {
+		t.Fatalf("expected ErrHelp; got %v", err)
+	}
+
+	expected := `test - greets people
+      --level|int|the level||[=3]
+  -n, --name|who|the who to greet| (default "bob")|
+FILE  |
+  a
+  b
+Other:
+one two three four
+  five six seven eight
+  nine ten
+`
+	if buf.String() != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, buf.String())
+	}
+}
+
+func TestUsageTemplateError(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.UsageTemplate = "{{.Unknown}}"
+
+	buf := new(bytes.Buffer)
+	fs.SetOutput(buf)
+	fs.usage()
+
+	if !strings.Contains(buf.String(), "can't evaluate field Unknown") {
+		t.Errorf("expected template error; got %q", buf.String())
+	}
+}
+
+func TestUsageData(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.String("name", "bob", "the `who` to greet", OptShorthand('n'))
+	fs.Bool("secret", false, "secret", OptHidden())
+
+	data := fs.UsageData(0)
+	if data.Name != "test" || data.Synopsis != "test [-h] [-n who]" {
+		t.Errorf("unexpected name or synopsis: %+v", data)
+	}
+	if len(data.Flags) != 1 || data.Flags[0].Name != "name" {
+		t.Errorf("expected hidden flags to be left out; got %v", data.Flags)
+	}
+	if data.FlagUsages != fs.GroupedFlagUsagesWrapped(0) {
+		t.Errorf("expected FlagUsages %q; got %q", fs.GroupedFlagUsagesWrapped(0), data.FlagUsages)
+	}
+}
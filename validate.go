@@ -0,0 +1,293 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator checks the value of a flag after it was set, see OptValidate.
+type Validator interface {
+	// Validate returns an error if the value is not valid. The value is the
+	// result of Get if the flag's Value is a Getter, and of String otherwise.
+	// Validators are called for each element of slice values.
+	Validate(value interface{}) error
+	// Constraint describes the valid values for usage messages, e.g.
+	// "1-65535", or is empty.
+	Constraint() string
+}
+
+// ConstraintFormatter is an optional interface for a FlagUsageFormatter to
+// format the constraints of flags with validators.
+type ConstraintFormatter interface {
+	Constraint(*Flag) string
+}
+
+// constraintNote returns the constraints of flag formatted by usageFormatter.
+func constraintNote(usageFormatter FlagUsageFormatter, flag *Flag) string {
+	if c, ok := usageFormatter.(ConstraintFormatter); ok {
+		return c.Constraint(flag)
+	}
+	return DefaultFlagUsageFormatter{}.Constraint(flag)
+}
+
+// Constraint describes the valid values of the flag according to its
+// validators, e.g. "1-65535", or is empty.
+func (f *Flag) Constraint() string {
+	var constraints []string
+	if f.SliceOptions != nil {
+		if c := f.SliceOptions.constraint(); c != "" {
+			constraints = append(constraints, c)
+		}
+	}
+	for _, v := range f.Validators {
+		if c := v.Constraint(); c != "" {
+			constraints = append(constraints, c)
+		}
+	}
+	return strings.Join(constraints, ", ")
+}
+
+// snapshotter is implemented by the slice values of this package to restore
+// their items, and whether they were set, exactly.
+type snapshotter interface {
+	snapshot() func()
+}
+
+// snapshotValue returns a function restoring the current state of value.
+// Values other than the slice values of this package are restored from their
+// text representation.
+func snapshotValue(value Value) func() {
+	switch v := value.(type) {
+	case snapshotter:
+		return v.snapshot()
+	case SliceValue:
+		items := v.GetSlice()
+		return func() { _ = v.Replace(items) }
+	}
+	text := value.String()
+	return func() { _ = value.Set(text) }
+}
+
+// setValidated sets the value of the flag with set and runs its validators,
+// restoring the previous value if they reject the new one.
+func (f *Flag) setValidated(set func() error) error {
+	if len(f.Validators) == 0 {
+		return set()
+	}
+	restore := snapshotValue(f.Value)
+	if err := set(); err != nil {
+		return err
+	}
+	if err := f.validate(); err != nil {
+		restore()
+		return err
+	}
+	return nil
+}
+
+// validate runs the validators of the flag on its current value.
+func (f *Flag) validate() error {
+	if len(f.Validators) == 0 {
+		return nil
+	}
+
+	var value interface{}
+	if g, ok := f.Value.(Getter); ok {
+		value = g.Get()
+	} else {
+		value = f.Value.String()
+	}
+
+	values := []interface{}{value}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		values = make([]interface{}, v.Len())
+		for i := range values {
+			values[i] = v.Index(i).Interface()
+		}
+	}
+
+	for _, validator := range f.Validators {
+		for _, value := range values {
+			if err := validator.Validate(value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// toBigFloat converts integer, unsigned integer and float values, including
+// time.Duration, to a *big.Float for exact comparisons.
+func toBigFloat(value interface{}) (*big.Float, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Float).SetUint64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(v.Float()) {
+			return nil, false
+		}
+		return new(big.Float).SetFloat64(v.Float()), true
+	}
+	return nil, false
+}
+
+// -- range Validator
+type rangeValidator struct {
+	min, max       interface{}
+	minVal, maxVal *big.Float
+}
+
+func newRangeValidator(min, max interface{}) *rangeValidator {
+	v := &rangeValidator{min: min, max: max}
+	var ok bool
+	if min != nil {
+		if v.minVal, ok = toBigFloat(min); !ok {
+			panic(fmt.Sprintf("invalid minimum %v, expected a number", min))
+		}
+	}
+	if max != nil {
+		if v.maxVal, ok = toBigFloat(max); !ok {
+			panic(fmt.Sprintf("invalid maximum %v, expected a number", max))
+		}
+	}
+	return v
+}
+
+func (v *rangeValidator) Validate(value interface{}) error {
+	n, ok := toBigFloat(value)
+	if !ok {
+		return fmt.Errorf("%v is not a number", value)
+	}
+	if (v.minVal != nil && n.Cmp(v.minVal) < 0) || (v.maxVal != nil && n.Cmp(v.maxVal) > 0) {
+		return fmt.Errorf("%v is out of range (%s)", value, v.Constraint())
+	}
+	return nil
+}
+
+func (v *rangeValidator) Constraint() string {
+	switch {
+	case v.max == nil:
+		return fmt.Sprintf(">=%v", v.min)
+	case v.min == nil:
+		return fmt.Sprintf("<=%v", v.max)
+	}
+
+	min, max := fmt.Sprint(v.min), fmt.Sprint(v.max)
+	if strings.HasPrefix(min, "-") || strings.HasPrefix(max, "-") {
+		return min + " to " + max
+	}
+	return min + "-" + max
+}
+
+// ValidateRange accepts numbers from min to max, inclusive. It works with all
+// int, uint, float and duration flags, e.g. ValidateRange(1, 65535) or
+// ValidateRange(time.Second, time.Minute). It panics if min or max is not a
+// number.
+func ValidateRange(min, max interface{}) Validator {
+	if min == nil || max == nil {
+		panic("range bounds must be set")
+	}
+	return newRangeValidator(min, max)
+}
+
+// ValidateMin accepts numbers greater than or equal to min, see ValidateRange.
+func ValidateMin(min interface{}) Validator {
+	if min == nil {
+		panic("minimum must be set")
+	}
+	return newRangeValidator(min, nil)
+}
+
+// ValidateMax accepts numbers less than or equal to max, see ValidateRange.
+func ValidateMax(max interface{}) Validator {
+	if max == nil {
+		panic("maximum must be set")
+	}
+	return newRangeValidator(nil, max)
+}
+
+// -- pattern Validator
+type patternValidator struct {
+	re *regexp.Regexp
+}
+
+func (v *patternValidator) Validate(value interface{}) error {
+	s := fmt.Sprint(value)
+	if !v.re.MatchString(s) {
+		return fmt.Errorf("%q does not match %s", s, v.re)
+	}
+	return nil
+}
+
+func (v *patternValidator) Constraint() string {
+	return "matching " + v.re.String()
+}
+
+// ValidatePattern accepts values whose text matches the regular expression.
+// Anchor the expression with ^ and $ to match the whole value. It panics if
+// the expression cannot be parsed.
+func ValidatePattern(pattern string) Validator {
+	return &patternValidator{re: regexp.MustCompile(pattern)}
+}
+
+// -- length Validator
+type lengthValidator struct {
+	min, max int
+}
+
+func (v *lengthValidator) Validate(value interface{}) error {
+	s := fmt.Sprint(value)
+	n := utf8.RuneCountInString(s)
+	if n < v.min || (v.max > 0 && n > v.max) {
+		return fmt.Errorf("length of %q is out of range (%s)", s, v.bounds())
+	}
+	return nil
+}
+
+func (v *lengthValidator) bounds() string {
+	if v.max <= 0 {
+		return fmt.Sprintf(">=%d", v.min)
+	}
+	return fmt.Sprintf("%d-%d", v.min, v.max)
+}
+
+func (v *lengthValidator) Constraint() string {
+	return "length " + v.bounds()
+}
+
+// ValidateLength accepts values whose text is min to max characters long. A
+// max of 0 means no maximum.
+func ValidateLength(min, max int) Validator {
+	return &lengthValidator{min: min, max: max}
+}
+
+// -- func Validator
+type funcValidator struct {
+	constraint string
+	fn         func(value interface{}) error
+}
+
+func (v *funcValidator) Validate(value interface{}) error {
+	return v.fn(value)
+}
+
+func (v *funcValidator) Constraint() string {
+	return v.constraint
+}
+
+// ValidateFunc accepts values for which fn returns nil. The constraint
+// describes the valid values in usage messages and can be empty.
+func ValidateFunc(constraint string, fn func(value interface{}) error) Validator {
+	return &funcValidator{constraint: constraint, fn: fn}
+}
@@ -0,0 +1,165 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateRange(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.Int("port", 8080, "port to listen on", OptShorthand('p'), OptValidate(ValidateRange(1, 65535)))
+	fs.Uint8("level", 0, "level", OptValidate(ValidateMax(3)))
+	fs.Float64("ratio", 0.5, "ratio", OptValidate(ValidateRange(0, 1)))
+	fs.Duration("timeout", time.Second, "timeout", OptValidate(ValidateRange(time.Second, time.Minute)))
+	fs.Int64("offset", 0, "offset", OptValidate(ValidateMin(-10)))
+	fs.IntSlice("ports", nil, "ports", OptValidate(ValidateRange(1, 65535)))
+	fs.SetErrorPresentation(ErrorSilent)
+
+	if err := fs.Parse([]string{"-p", "443", "--level=3", "--ratio=1", "--timeout=1m", "--offset=-10", "--ports=80,443"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		arg      string
+		expected string
+	}{
+		{"--port=70000", `invalid argument "70000" for "-p, --port" flag: 70000 is out of range (1-65535)`},
+		{"--port=0", `invalid argument "0" for "-p, --port" flag: 0 is out of range (1-65535)`},
+		{"--level=4", `invalid argument "4" for "--level" flag: 4 is out of range (<=3)`},
+		{"--ratio=1.01", `invalid argument "1.01" for "--ratio" flag: 1.01 is out of range (0-1)`},
+		{"--ratio=NaN", `invalid argument "NaN" for "--ratio" flag: NaN is not a number`},
+		{"--timeout=500ms", `invalid argument "500ms" for "--timeout" flag: 500ms is out of range (1s-1m0s)`},
+		{"--offset=-11", `invalid argument "-11" for "--offset" flag: -11 is out of range (>=-10)`},
+		{"--ports=80,0", `invalid argument "80,0" for "--ports" flag: 0 is out of range (1-65535)`},
+	}
+	for _, test := range tests {
+		err := fs.Parse([]string{test.arg})
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("%s: expected %q; got %v", test.arg, test.expected, err)
+		}
+		if ErrorCategoryOf(err) != ErrorInvalidValue {
+			t.Errorf("%s: expected an invalid value error; got %v", test.arg, ErrorCategoryOf(err))
+		}
+	}
+
+	// rejected values are not kept
+	for name, expected := range map[string]string{
+		"port": "443", "level": "3", "ratio": "1", "timeout": "1m0s", "offset": "-10", "ports": "[80,443]",
+	} {
+		if value := fs.Lookup(name).Value.String(); value != expected {
+			t.Errorf("expected --%s to keep %s; got %s", name, expected, value)
+		}
+	}
+}
+
+func TestValidateRestore(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	ports := fs.IntSlice("ports", nil, "ports", OptValidate(ValidateMin(1)))
+	ratios := fs.Float64Slice("ratios", nil, "ratios", OptValidate(ValidateMax(1)))
+	fs.SetErrorPresentation(ErrorSilent)
+
+	if err := fs.Parse([]string{"--ports=1", "--ports=0"}); err == nil {
+		t.Error("expected an error")
+	}
+	if err := fs.Set("ports", "2"); err != nil {
+		t.Fatal(err)
+	}
+	if len(*ports) != 2 || (*ports)[0] != 1 || (*ports)[1] != 2 {
+		t.Errorf("expected [1 2]; got %v", *ports)
+	}
+
+	if err := fs.Parse([]string{"--ratios=0.0000001", "--ratios=2"}); err == nil {
+		t.Error("expected an error")
+	}
+	if len(*ratios) != 1 || (*ratios)[0] != 0.0000001 {
+		t.Errorf("expected the exact previous items; got %v", *ratios)
+	}
+}
+
+func TestValidateText(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.String("name", "", "name", OptValidate(ValidatePattern(`^[a-z][a-z0-9-]*$`), ValidateLength(2, 8)))
+	fs.StringSlice("tags", nil, "tags", OptValidate(ValidateLength(1, 0)))
+	fs.Int("workers", 2, "workers", OptValidate(ValidateFunc("even", func(value interface{}) error {
+		if value.(int)%2 != 0 {
+			return errors.New("must be even")
+		}
+		return nil
+	})))
+	fs.SetErrorPresentation(ErrorSilent)
+
+	if err := fs.Parse([]string{"--name=web-1", "--tags=a,b", "--workers=4"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		arg      string
+		expected string
+	}{
+		{"--name=Web", `invalid argument "Web" for "--name" flag: "Web" does not match ^[a-z][a-z0-9-]*$`},
+		{"--name=w", `invalid argument "w" for "--name" flag: length of "w" is out of range (2-8)`},
+		{"--name=webserver", `invalid argument "webserver" for "--name" flag: length of "webserver" is out of range (2-8)`},
+		{"--tags=a,,b", `invalid argument "a,,b" for "--tags" flag: length of "" is out of range (>=1)`},
+		{"--workers=3", `invalid argument "3" for "--workers" flag: must be even`},
+	}
+	for _, test := range tests {
+		err := fs.Parse([]string{test.arg})
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("%s: expected %q; got %v", test.arg, test.expected, err)
+		}
+	}
+}
+
+func TestValidateUsage(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.Int("port", 8080, "port to listen on", OptValidate(ValidateRange(1, 65535)))
+	fs.String("name", "", "name", OptValidate(ValidateLength(2, 8), ValidateFunc("", func(interface{}) error { return nil })))
+	fs.Int("delta", 0, "delta", OptValidate(ValidateRange(-5, 5)))
+
+	expected := "      --delta int     delta (-5 to 5)\n" +
+		"      --name string   name (length 2-8)\n" +
+		"      --port int      port to listen on (1-65535) (default 8080)\n"
+	if usage := fs.FlagUsages(); usage != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, usage)
+	}
+
+	if doc := fs.HelpDocument(); doc.Flags[2].Constraint != "1-65535" {
+		t.Errorf("expected the constraint in the help document; got %q", doc.Flags[2].Constraint)
+	}
+}
+
+func TestValidateDefaultFunc(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.Int("port", 0, "port", OptValidate(ValidateMin(1)),
+		OptDefaultFunc(func(fs *FlagSet) (string, error) { return "0", nil }))
+	fs.SetErrorPresentation(ErrorSilent)
+
+	err := fs.Parse(nil)
+	if err == nil || err.Error() != `invalid default value "0" for flag --port: 0 is out of range (>=1)` {
+		t.Errorf("expected computed defaults to be validated; got %v", err)
+	}
+
+	fs = NewFlagSet("test", ContinueOnError)
+	fs.Int("port", 8080, "port", OptValidate(ValidateMin(1)),
+		OptDefaultFunc(func(fs *FlagSet) (string, error) { return "-1", nil }))
+	fs.SetErrorPresentation(ErrorSilent)
+	if err := fs.Parse(nil); err == nil {
+		t.Error("expected an error")
+	}
+	if v := fs.MustGetInt("port"); v != 8080 {
+		t.Errorf("expected the rejected default not to be kept; got %d", v)
+	}
+}
+
+func TestValidateInvalidBounds(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a bound that is not a number")
+		}
+	}()
+	ValidateRange("1", 10)
+}
@@ -0,0 +1,132 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"text/template"
+)
+
+// ErrVersion is the error returned if the flag --version is invoked, see
+// FlagSet.SetVersion.
+var ErrVersion = errors.New("zflag: version requested")
+
+// DefaultVersionTemplate is the template used to print the version, see
+// FlagSet.VersionTemplate.
+const DefaultVersionTemplate = `{{.Name}} version {{.Version}}{{with .Revision}} ({{.}}{{if $.Modified}}, modified{{end}}){{end}}
+`
+
+// VersionInfo is the data printed by the --version flag. Empty fields are
+// filled from the build information of the binary, see BuildVersionInfo.
+type VersionInfo struct {
+	// Name is the name of the program, defaults to the name of the FlagSet.
+	Name string
+	// Version is the version of the program, defaults to the version of the
+	// main module, e.g. "v1.2.3" or "(devel)".
+	Version string
+	// Revision is the VCS revision the program was built from.
+	Revision string
+	// Time is the time of the VCS revision, in RFC3339 format.
+	Time string
+	// Modified reports whether the VCS working tree had local modifications.
+	Modified bool
+	// GoVersion is the version of Go used to build the program.
+	GoVersion string
+}
+
+// BuildVersionInfo returns the VersionInfo of the running binary, as reported
+// by runtime/debug.ReadBuildInfo. The VCS fields require Go 1.18 or later and
+// a binary built from a VCS checkout.
+func BuildVersionInfo() VersionInfo {
+	info := VersionInfo{GoVersion: runtime.Version()}
+
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	info.Version = bi.Main.Version
+	readBuildSettings(bi, &info)
+
+	return info
+}
+
+// SetVersion defines the --version flag, which prints info using the
+// VersionTemplate and makes parsing return ErrVersion, like --help returns
+// ErrHelp. Empty fields of info are filled from BuildVersionInfo, Modified
+// together with Revision. Options are applied to the flag, e.g.
+// OptShorthand('V').
+func (f *FlagSet) SetVersion(info VersionInfo, opts ...Opt) {
+	info = fillVersionInfo(info, BuildVersionInfo())
+
+	f.version = &info
+	f.versionFlag = f.Var(newBoolValue(false, new(bool)), "version", "print version information and exit", append(opts, OptNoOptDefVal("true"))...)
+}
+
+// fillVersionInfo fills each empty field of info from build.
+func fillVersionInfo(info, build VersionInfo) VersionInfo {
+	if info.Version == "" {
+		info.Version = build.Version
+		if info.Version == "" {
+			info.Version = "(devel)"
+		}
+	}
+	if info.Revision == "" {
+		info.Revision = build.Revision
+		info.Modified = build.Modified
+	}
+	if info.Time == "" {
+		info.Time = build.Time
+	}
+	if info.GoVersion == "" {
+		info.GoVersion = build.GoVersion
+	}
+	return info
+}
+
+// Version returns the VersionInfo set with SetVersion, or nil.
+func (f *FlagSet) Version() *VersionInfo {
+	return f.version
+}
+
+// handleVersion prints the version and returns ErrVersion if flag is the
+// --version flag and value requests the version.
+func (f *FlagSet) handleVersion(flag *Flag, value string) (bool, error) {
+	if flag == nil || flag != f.versionFlag {
+		return false, nil
+	}
+	if requested, err := strconv.ParseBool(value); err != nil || !requested {
+		return false, nil
+	}
+	if err := f.PrintVersion(); err != nil {
+		return true, err
+	}
+	return true, ErrVersion
+}
+
+// PrintVersion prints the VersionInfo set with SetVersion to the FlagSet's
+// output, using the VersionTemplate.
+func (f *FlagSet) PrintVersion() error {
+	if f.version == nil {
+		return fmt.Errorf("no version set for %s", f.name)
+	}
+
+	info := *f.version
+	if info.Name == "" {
+		info.Name = f.name
+	}
+
+	text := f.VersionTemplate
+	if text == "" {
+		text = DefaultVersionTemplate
+	}
+	tmpl, err := template.New("version").Parse(text)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(f.Output(), info)
+}
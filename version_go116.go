@@ -0,0 +1,13 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !go1.18
+// +build !go1.18
+
+package zflag
+
+import "runtime/debug"
+
+// readBuildSettings does nothing, build settings are only recorded since
+// Go 1.18.
+func readBuildSettings(bi *debug.BuildInfo, info *VersionInfo) {}
@@ -0,0 +1,24 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package zflag
+
+import "runtime/debug"
+
+// readBuildSettings fills the VCS fields of info from the build settings.
+func readBuildSettings(bi *debug.BuildInfo, info *VersionInfo) {
+	info.GoVersion = bi.GoVersion
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.time":
+			info.Time = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+)
+
+func TestVersionFlag(t *testing.T) {
+	for _, args := range [][]string{{"--version"}, {"-V"}, {"-vV", "arg"}, {"--version=true"}} {
+		fs := NewFlagSet("test", ContinueOnError)
+		fs.Bool("verbose", false, "verbose", OptShorthand('v'))
+		fs.SetVersion(VersionInfo{Version: "v1.2.3", Revision: "abc123", Modified: true}, OptShorthand('V'))
+
+		buf := new(bytes.Buffer)
+		fs.SetOutput(buf)
+		err := fs.Parse(args)
+		if err != ErrVersion {
+			t.Fatalf("%v: expected ErrVersion; got %v", args, err)
+		}
+		if buf.String() != "test version v1.2.3 (abc123, modified)\n" {
+			t.Errorf("%v: unexpected output %q", args, buf.String())
+		}
+	}
+}
+
+func TestVersionFlagNotRequested(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetVersion(VersionInfo{Version: "v1.2.3"})
+
+	buf := new(bytes.Buffer)
+	fs.SetOutput(buf)
+	if err := fs.Parse([]string{"--version=false"}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output; got %q", buf.String())
+	}
+	if fs.Lookup("version") == nil {
+		t.Error("expected the version flag to be defined")
+	}
+}
+
+func TestVersionTemplate(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetVersion(VersionInfo{Name: "tool", Version: "v2.0.0", GoVersion: "go1.16"})
+	fs.VersionTemplate = "{{.Name}} {{.Version}} built with {{.GoVersion}}\n"
+
+	buf := new(bytes.Buffer)
+	fs.SetOutput(buf)
+	if err := fs.Parse([]string{"--version"}); err != ErrVersion {
+		t.Fatalf("expected ErrVersion; got %v", err)
+	}
+	if buf.String() != "tool v2.0.0 built with go1.16\n" {
+		t.Errorf("unexpected output %q", buf.String())
+	}
+}
+
+func TestBuildVersionInfo(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetVersion(VersionInfo{})
+
+	info := fs.Version()
+	if info.Version == "" {
+		t.Error("expected the version to be filled from the build info")
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Errorf("expected Go version %q; got %q", runtime.Version(), info.GoVersion)
+	}
+}
+
+func TestFillVersionInfo(t *testing.T) {
+	build := VersionInfo{Version: "v1.0.0", Revision: "abc123", Time: "2021-01-02T03:04:05Z", Modified: true, GoVersion: "go1.16"}
+
+	info := fillVersionInfo(VersionInfo{Version: "v2.0.0"}, build)
+	expected := VersionInfo{Version: "v2.0.0", Revision: "abc123", Time: "2021-01-02T03:04:05Z", Modified: true, GoVersion: "go1.16"}
+	if info != expected {
+		t.Errorf("expected %+v; got %+v", expected, info)
+	}
+
+	info = fillVersionInfo(VersionInfo{Revision: "def456", Time: "2022-01-01T00:00:00Z"}, build)
+	expected = VersionInfo{Version: "v1.0.0", Revision: "def456", Time: "2022-01-01T00:00:00Z", GoVersion: "go1.16"}
+	if info != expected {
+		t.Errorf("expected %+v; got %+v", expected, info)
+	}
+}
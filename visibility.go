@@ -0,0 +1,86 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Visibility defines the help level at which a flag is shown in help/usage
+// text. Flags are shown if their visibility is at most the HelpVisibility of
+// the FlagSet.
+type Visibility int
+
+const (
+	// VisibilityBasic flags are always shown. This is the default.
+	VisibilityBasic Visibility = iota
+	// VisibilityAdvanced flags are shown with --help=advanced or --help-all.
+	VisibilityAdvanced
+	// VisibilityDebug flags are shown with --help=debug or --help-all.
+	VisibilityDebug
+)
+
+var visibilityNames = []string{"basic", "advanced", "debug"}
+
+func (v Visibility) String() string {
+	if v >= 0 && int(v) < len(visibilityNames) {
+		return visibilityNames[v]
+	}
+	return fmt.Sprintf("Visibility(%d)", int(v))
+}
+
+// parseVisibility returns the Visibility for the help level name s, where
+// "all" is the highest level.
+func parseVisibility(s string) (Visibility, bool) {
+	if s == "all" {
+		return VisibilityDebug, true
+	}
+	for i, name := range visibilityNames {
+		if s == name {
+			return Visibility(i), true
+		}
+	}
+	return 0, false
+}
+
+// helpFooter returns the note printed below the usage message when flags are
+// left out because of their visibility. counts holds the number of left out
+// flags per visibility.
+func (f *FlagSet) helpFooter(counts map[Visibility]int) string {
+	n := 0
+	maxLevel := f.HelpVisibility
+	for level, count := range counts {
+		n += count
+		if level > maxLevel {
+			maxLevel = level
+		}
+	}
+	if n == 0 {
+		return ""
+	}
+
+	var footer string
+	if n == 1 {
+		footer = "1 more flag is not shown"
+	} else {
+		footer = fmt.Sprintf("%d more flags are not shown", n)
+	}
+	if f.DisableBuiltinHelp {
+		return footer + "."
+	}
+
+	var options []string
+	for level := f.HelpVisibility + 1; level < maxLevel; level++ {
+		if counts[level] > 0 {
+			options = append(options, "--help="+level.String())
+		}
+	}
+	options = append(options, "--help-all")
+
+	if n == 1 {
+		return fmt.Sprintf("%s, use %s to see it.", footer, strings.Join(options, " or "))
+	}
+	return fmt.Sprintf("%s, use %s to see them.", footer, strings.Join(options, " or "))
+}
@@ -0,0 +1,129 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func setUpVisibilityFlagSet(buf *bytes.Buffer) *FlagSet {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetOutput(buf)
+	fs.Bool("verbose", false, "verbose output")
+	fs.Int("workers", 4, "number of workers", OptVisibility(VisibilityAdvanced))
+	fs.Int("buffer", 0, "buffer size", OptVisibility(VisibilityAdvanced))
+	fs.Bool("trace", false, "trace execution", OptVisibility(VisibilityDebug))
+	return fs
+}
+
+func TestVisibilityHelpLevels(t *testing.T) {
+	tests := []struct {
+		args     []string
+		shown    []string
+		notShown []string
+		footer   string
+	}{
+		{
+			args:     []string{"--help"},
+			shown:    []string{"--verbose"},
+			notShown: []string{"--workers", "--buffer", "--trace"},
+			footer:   "3 more flags are not shown, use --help=advanced or --help-all to see them.",
+		},
+		{
+			args:     []string{"-h"},
+			shown:    []string{"--verbose"},
+			notShown: []string{"--workers", "--buffer", "--trace"},
+			footer:   "3 more flags are not shown, use --help=advanced or --help-all to see them.",
+		},
+		{
+			args:     []string{"--help=advanced"},
+			shown:    []string{"--verbose", "--workers", "--buffer"},
+			notShown: []string{"--trace"},
+			footer:   "1 more flag is not shown, use --help-all to see it.",
+		},
+		{
+			args:  []string{"--help-all"},
+			shown: []string{"--verbose", "--workers", "--buffer", "--trace"},
+		},
+		{
+			args:  []string{"--help=all"},
+			shown: []string{"--verbose", "--workers", "--buffer", "--trace"},
+		},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		fs := setUpVisibilityFlagSet(&buf)
+
+		if err := fs.Parse(tt.args); err != ErrHelp {
+			t.Fatalf("%v: expected ErrHelp; got %v", tt.args, err)
+		}
+		out := buf.String()
+		for _, name := range tt.shown {
+			if !strings.Contains(out, name) {
+				t.Errorf("%v: expected %s in usage:\n%s", tt.args, name, out)
+			}
+		}
+		for _, name := range tt.notShown {
+			if strings.Contains(out, name) {
+				t.Errorf("%v: expected %s not to be in usage:\n%s", tt.args, name, out)
+			}
+		}
+		if tt.footer != "" && !strings.HasSuffix(out, "\n\n"+tt.footer+"\n") {
+			t.Errorf("%v: expected footer %q in usage:\n%s", tt.args, tt.footer, out)
+		}
+		if tt.footer == "" && strings.Contains(out, "not shown") {
+			t.Errorf("%v: expected no footer in usage:\n%s", tt.args, out)
+		}
+		if fs.HelpVisibility != VisibilityBasic {
+			t.Errorf("%v: expected HelpVisibility to be restored; got %s", tt.args, fs.HelpVisibility)
+		}
+	}
+}
+
+func TestVisibilityFooterOnlyHiddenLevels(t *testing.T) {
+	var buf bytes.Buffer
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetOutput(&buf)
+	fs.Bool("verbose", false, "verbose output")
+	fs.Bool("trace", false, "trace execution", OptVisibility(VisibilityDebug))
+
+	if err := fs.Parse([]string{"--help"}); err != ErrHelp {
+		t.Fatalf("expected ErrHelp; got %v", err)
+	}
+	if want := "\n1 more flag is not shown, use --help-all to see it.\n"; !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("expected footer %q; got:\n%s", want, buf.String())
+	}
+}
+
+func TestVisibilityDisableBuiltinHelp(t *testing.T) {
+	var buf bytes.Buffer
+	fs := setUpVisibilityFlagSet(&buf)
+	fs.DisableBuiltinHelp = true
+
+	if err := fs.Parse([]string{"--help-all"}); err == nil || err == ErrHelp {
+		t.Fatalf("expected unknown flag error; got %v", err)
+	}
+
+	buf.Reset()
+	fs.PrintDefaults()
+	if want := "\n3 more flags are not shown.\n"; !strings.HasSuffix(buf.String(), want) {
+		t.Errorf("expected footer %q; got:\n%s", want, buf.String())
+	}
+}
+
+func TestVisibilityDefinedHelpAll(t *testing.T) {
+	var buf bytes.Buffer
+	fs := setUpVisibilityFlagSet(&buf)
+	helpAll := fs.Bool("help-all", false, "custom help-all")
+
+	if err := fs.Parse([]string{"--help-all"}); err != nil {
+		t.Fatalf("expected no error for defined --help-all; got %v", err)
+	}
+	if !*helpAll {
+		t.Error("expected --help-all to be set")
+	}
+}
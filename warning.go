@@ -0,0 +1,116 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+)
+
+// WarningKind identifies the event a Warning is about. More kinds may be
+// added in the future, handlers should handle unknown kinds gracefully.
+type WarningKind int
+
+const (
+	// WarningDeprecatedFlag is emitted when a deprecated flag is set.
+	WarningDeprecatedFlag WarningKind = iota
+	// WarningDeprecatedShorthand is emitted when a deprecated shorthand is
+	// used.
+	WarningDeprecatedShorthand
+)
+
+var warningKindNames = []string{"deprecated flag", "deprecated shorthand"}
+
+func (k WarningKind) String() string {
+	if k >= 0 && int(k) < len(warningKindNames) {
+		return warningKindNames[k]
+	}
+	return fmt.Sprintf("WarningKind(%d)", int(k))
+}
+
+// Warning is an event reported to the WarningHandler of a FlagSet. It is also
+// an error, so handlers can return it to turn the event into an error.
+type Warning struct {
+	// Kind identifies the event.
+	Kind WarningKind
+	// Flag is the flag the event is about.
+	Flag *Flag
+	// Message is the human readable description of the event, e.g.
+	// "Flag --old has been deprecated, use --new instead".
+	Message string
+}
+
+func (w *Warning) Error() string {
+	return w.Message
+}
+
+// WarningHandler handles the warnings of a FlagSet. A non-nil error returned
+// by HandleWarning is returned by Set without setting the value, and makes
+// parsing fail with the ErrorWarning category.
+type WarningHandler interface {
+	HandleWarning(f *FlagSet, w *Warning) error
+}
+
+// WarningHandlerFunc is an adapter to use a function as a WarningHandler.
+type WarningHandlerFunc func(f *FlagSet, w *Warning) error
+
+func (fn WarningHandlerFunc) HandleWarning(f *FlagSet, w *Warning) error {
+	return fn(f, w)
+}
+
+// DefaultWarningHandler prints the message of warnings to the output of the
+// FlagSet. It is used if no WarningHandler is set.
+var DefaultWarningHandler WarningHandler = WarningHandlerFunc(func(f *FlagSet, w *Warning) error {
+	fmt.Fprintln(f.Output(), w.Message)
+	return nil
+})
+
+// CollectWarnings returns a WarningHandler that appends warnings to *dst
+// instead of printing them.
+func CollectWarnings(dst *[]*Warning) WarningHandler {
+	return WarningHandlerFunc(func(f *FlagSet, w *Warning) error {
+		*dst = append(*dst, w)
+		return nil
+	})
+}
+
+// PromoteWarnings returns a WarningHandler that turns warnings of the given
+// kinds into errors, or all warnings without kinds. Other warnings are passed
+// to the DefaultWarningHandler.
+func PromoteWarnings(kinds ...WarningKind) WarningHandler {
+	return WarningHandlerFunc(func(f *FlagSet, w *Warning) error {
+		if len(kinds) == 0 {
+			return w
+		}
+		for _, kind := range kinds {
+			if w.Kind == kind {
+				return w
+			}
+		}
+		return DefaultWarningHandler.HandleWarning(f, w)
+	})
+}
+
+// LogWarnings returns a WarningHandler that passes warnings to a structured
+// logging function, with the message and the "kind" and "flag" attributes as
+// alternating keys and values. The signature matches the Warn method of a
+// log/slog Logger.
+func LogWarnings(log func(msg string, args ...interface{})) WarningHandler {
+	return WarningHandlerFunc(func(f *FlagSet, w *Warning) error {
+		args := []interface{}{"kind", w.Kind.String()}
+		if w.Flag != nil {
+			args = append(args, "flag", w.Flag.Name)
+		}
+		log(w.Message, args...)
+		return nil
+	})
+}
+
+// warn passes w to the WarningHandler of the FlagSet.
+func (f *FlagSet) warn(w *Warning) error {
+	handler := f.WarningHandler
+	if handler == nil {
+		handler = DefaultWarningHandler
+	}
+	return handler.HandleWarning(f, w)
+}
@@ -0,0 +1,97 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func newDeprecatedFlagSet() (*FlagSet, *bytes.Buffer) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.Bool("old", false, "old flag", OptDeprecated("use --new instead"))
+	fs.Bool("new", false, "new flag", OptShorthand('n'), OptShorthandDeprecated("use --new instead"))
+	fs.SetErrorPresentation(ErrorShowHint)
+	buf := new(bytes.Buffer)
+	fs.SetOutput(buf)
+	return fs, buf
+}
+
+func TestDefaultWarningHandler(t *testing.T) {
+	fs, buf := newDeprecatedFlagSet()
+	if err := fs.Parse([]string{"--old", "-n"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "Flag --old has been deprecated, use --new instead\n" +
+		"Flag shorthand -n has been deprecated, use --new instead\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q; got %q", expected, buf.String())
+	}
+}
+
+func TestCollectWarnings(t *testing.T) {
+	fs, buf := newDeprecatedFlagSet()
+	var warnings []*Warning
+	fs.WarningHandler = CollectWarnings(&warnings)
+	if err := fs.Parse([]string{"--old", "-n"}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output; got %q", buf.String())
+	}
+	if len(warnings) != 2 {
+		t.Fatalf("expected 2 warnings; got %d", len(warnings))
+	}
+	if warnings[0].Kind != WarningDeprecatedFlag || warnings[0].Flag.Name != "old" {
+		t.Errorf("unexpected warning %+v", warnings[0])
+	}
+	if warnings[1].Kind != WarningDeprecatedShorthand || warnings[1].Flag.Name != "new" {
+		t.Errorf("unexpected warning %+v", warnings[1])
+	}
+}
+
+func TestPromoteWarnings(t *testing.T) {
+	fs, buf := newDeprecatedFlagSet()
+	fs.WarningHandler = PromoteWarnings(WarningDeprecatedShorthand)
+	if err := fs.Parse([]string{"--old"}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "Flag --old has been deprecated, use --new instead\n" {
+		t.Errorf("expected the warning to be printed; got %q", buf.String())
+	}
+
+	err := fs.Parse([]string{"-n"})
+	if ErrorCategoryOf(err) != ErrorWarning {
+		t.Fatalf("expected a warning error; got %v", err)
+	}
+
+	fs, _ = newDeprecatedFlagSet()
+	fs.WarningHandler = PromoteWarnings()
+	err = fs.Parse([]string{"--old"})
+	if ErrorCategoryOf(err) != ErrorWarning || err.Error() != "Flag --old has been deprecated, use --new instead" {
+		t.Fatalf("expected a warning error; got %v", err)
+	}
+	if err := fs.Set("old", "true"); err == nil {
+		t.Error("expected Set to return the warning")
+	}
+	if flag := fs.Lookup("old"); flag.Changed || flag.Value.String() != "false" {
+		t.Errorf("expected the flag not to be set; got %s", flag.Value)
+	}
+}
+
+func TestLogWarnings(t *testing.T) {
+	fs, _ := newDeprecatedFlagSet()
+	var logged []string
+	fs.WarningHandler = LogWarnings(func(msg string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintln(append([]interface{}{msg}, args...)...))
+	})
+	if err := fs.Parse([]string{"--old"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "Flag --old has been deprecated, use --new instead kind deprecated flag flag old\n"
+	if len(logged) != 1 || logged[0] != expected {
+		t.Errorf("expected %q; got %q", expected, logged)
+	}
+}
@@ -0,0 +1,87 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"über", 4},
+		{"e\u0301", 1},     // combining acute accent
+		{"a\u200bb", 2},    // zero width space
+		{"日本語", 6},         // wide CJK
+		{"ｆｕｌｌ", 8},        // fullwidth latin
+		{"한국", 4},          // hangul syllables
+		{"\U0001F600", 2},  // emoji
+		{"a\u200db", 2},    // zero width joiner
+		{"tab\there", 7},   // control characters have no width
+		{"\U00020000x", 3}, // CJK extension B
+	}
+
+	for _, tt := range tests {
+		if got := displayWidth(tt.input); got != tt.expected {
+			t.Errorf("displayWidth(%q): expected %d; got %d", tt.input, tt.expected, got)
+		}
+	}
+}
+
+func TestDisplayIndex(t *testing.T) {
+	tests := []struct {
+		input    string
+		cols     int
+		expected int
+	}{
+		{"abcdef", 3, 3},
+		{"abc", 10, 3},
+		{"日本語", 3, 3},
+		{"日本語", 4, 6},
+		{"éé", 1, 3},
+	}
+
+	for _, tt := range tests {
+		if got := displayIndex(tt.input, tt.cols); got != tt.expected {
+			t.Errorf("displayIndex(%q, %d): expected %d; got %d", tt.input, tt.cols, tt.expected, got)
+		}
+	}
+}
+
+func TestWrapWideRunes(t *testing.T) {
+	s := strings.Repeat("日本 ", 20)
+	for _, line := range strings.Split(wrap(0, 40, s), "\n") {
+		if w := displayWidth(line); w > 40 {
+			t.Errorf("line %q is %d columns wide, expected at most 40", line, w)
+		}
+	}
+}
+
+func TestUsageAlignmentNonASCII(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.Bool("ascii", false, "first", OptShorthand('a'))
+	fs.Bool("größe", false, "second", OptShorthand('ü'))
+	fs.String("名前", "", "third")
+
+	lines := strings.Split(strings.TrimSuffix(fs.FlagUsages(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines; got %q", lines)
+	}
+	col := -1
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		usage := fields[len(fields)-1]
+		c := displayWidth(line[:strings.LastIndex(line, usage)])
+		if col == -1 {
+			col = c
+		} else if c != col {
+			t.Errorf("usage of %q starts at column %d, expected %d", line, c, col)
+		}
+	}
+}